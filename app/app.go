@@ -8,6 +8,8 @@ import (
 	"fyne.io/fyne/v2/widget"
 
 	"graphmis/app/ui"
+	"graphmis/experiment"
+	"graphmis/graph"
 )
 
 func NewApp() fyne.Window {
@@ -19,10 +21,10 @@ func NewApp() fyne.Window {
 	w.CenterOnScreen()
 
 	state := &ui.AppState{
-		GeneratorConfig: &ui.GeneratorConfig{},
-		MethodConfigs:   make([]ui.MethodConfig, 0, 2),
-		RunConfig:       &ui.RunConfig{},
-		Results:         make([]*ui.Result, 0),
+		GeneratorConfig: &experiment.GeneratorConfig{},
+		Registry:        graph.DefaultRegistry[string](),
+		RunConfig:       &experiment.RunConfig{},
+		Results:         make([]*experiment.Result, 0),
 		NavigationState: &ui.NavigationState{},
 	}
 
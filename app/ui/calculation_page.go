@@ -2,17 +2,20 @@ package ui
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"maps"
+	"slices"
 	"sync/atomic"
 	"time"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/dialog"
 	"fyne.io/fyne/v2/widget"
 
-	"graphmis/app/utils"
-	"graphmis/graph"
+	"graphmis/experiment"
 )
 
 func NewCalculationPage(state *AppState) (fyne.CanvasObject, func()) {
@@ -28,7 +31,6 @@ func NewCalculationPage(state *AppState) (fyne.CanvasObject, func()) {
 
 	var cancel context.CancelFunc
 	var isRunning atomic.Bool
-	var wasCancelled atomic.Bool
 
 	appendLog := func(msg string) {
 		now := time.Now().Format("15:04:05")
@@ -47,12 +49,11 @@ func NewCalculationPage(state *AppState) (fyne.CanvasObject, func()) {
 
 	resetUI := func() {
 		isRunning.Store(false)
-		wasCancelled.Store(false)
 
 		runBtn.Enable()
 		cancelBtn.Disable()
 
-		if !wasCancelled.Load() && len(state.Results) > 0 {
+		if len(state.Results) > 0 {
 			state.NavigationState.NextButton.Enable()
 		} else {
 			state.NavigationState.NextButton.Disable()
@@ -72,10 +73,7 @@ func NewCalculationPage(state *AppState) (fyne.CanvasObject, func()) {
 			cancel()
 			cancel = nil
 		}
-		wasCancelled.Store(true)
-		state.Results = nil
-
-		_ = progressVal.Set(1.0)
+		cancelBtn.Disable()
 	}
 
 	runBtn.OnTapped = func() {
@@ -83,7 +81,6 @@ func NewCalculationPage(state *AppState) (fyne.CanvasObject, func()) {
 			return
 		}
 		isRunning.Store(true)
-		wasCancelled.Store(false)
 
 		clearLog()
 		runBtn.Disable()
@@ -97,89 +94,67 @@ func NewCalculationPage(state *AppState) (fyne.CanvasObject, func()) {
 		ctx, c := context.WithCancel(context.Background())
 		cancel = c
 
-		go func() {
-			defer func() {
-				_ = progressVal.Set(1.0)
-			}()
-
-			totalSteps := float64(state.RunConfig.RunsNumber * 2)
-			var currentStep float64
-
-		loop:
-			for i := range state.RunConfig.RunsNumber {
-				if ctx.Err() != nil {
-					break loop
-				}
+		exp := experiment.Experiment{
+			Graph:   state.Graph,
+			Run:     *state.RunConfig,
+			Solvers: slices.Clone(state.Solvers),
+		}
+		if state.GeneratorConfig != nil {
+			cfg := *state.GeneratorConfig
+			exp.Generator = &cfg
+		}
+		if state.Sweep != nil {
+			sweep := *state.Sweep
+			exp.Sweep = &sweep
+		}
 
-				var g graph.Graph[string]
-				if state.RunConfig.IsGraphFixed || i == 0 {
-					g = state.Graph
-				} else {
-					if state.GeneratorConfig == nil {
-						appendLog("❌ Невозможно сгенерировать граф: конфигурация отсутствует")
-						continue
+		go func() {
+			for ev := range experiment.Run(ctx, exp) {
+				switch ev.Kind {
+				case experiment.RunStarted:
+					if ev.RunId > 1 {
+						appendLog("")
 					}
-					g = utils.GenerateGraph(
-						state.GeneratorConfig.GraphType,
-						state.GeneratorConfig.MinVerticesNumber,
-						state.GeneratorConfig.MaxVerticesNumber,
-						state.GeneratorConfig.GraphDensity,
-					)
-					state.Graph = g
-				}
-
-				appendLog(fmt.Sprintf("🔄 Итерация #%d", i+1))
-
-				var exactSolution []string
-
-				for _, mc := range state.MethodConfigs {
-					if ctx.Err() != nil {
-						break loop
+					if ev.Cell != nil {
+						appendLog(fmt.Sprintf("🔄 Итерация #%d, %v (зерно графа: %d)", ev.RunId, *ev.Cell, ev.GraphSeed))
+					} else {
+						appendLog(fmt.Sprintf("🔄 Итерация #%d (зерно графа: %d)", ev.RunId, ev.GraphSeed))
 					}
-
-					methodName := mc.MethodType()
-					var solution []string
-
-					start := time.Now()
-					switch cfg := mc.(type) {
-					case *MaghoutConfig:
-						exactSolution = graph.MISMaghout(ctx, g, cfg.ParallelDepth)
-						solution = exactSolution
-					case *GreedySearchConfig:
-						solution = graph.MISGreedySearch(ctx, g, cfg.Iterations)
+					appendLog(fmt.Sprintf("Оценки α: %s", ev.Bounds))
+				case experiment.RunSkipped:
+					appendLog(fmt.Sprintf("❌ Итерация #%d пропущена: %v", ev.RunId, ev.Err))
+				case experiment.MethodFinished:
+					res := ev.Result
+					state.Results = append(state.Results, res)
+
+					for _, name := range slices.Sorted(maps.Keys(ev.Stats.Counters)) {
+						appendLog(fmt.Sprintf("[%s] %s = %d", res.Method, name, ev.Stats.Counters[name]))
 					}
-					elapsed := time.Since(start).Nanoseconds()
-
-					var f1 float64
-					if _, ok := mc.(*GreedySearchConfig); ok {
-						f1 = graph.ComputeF1Factor(exactSolution, solution)
-					} else {
-						f1 = 1.0
+					appendLog(fmt.Sprintf("[%s] Время: %d нс | F1: %s | Вес: %g | Зерно: %d", res.Method, res.Time, res.F1Label(), res.Weight, res.Seed))
+					if !res.Check.Valid() {
+						appendLog(fmt.Sprintf("[%s] ❗ Некорректное решение: %s", res.Method, res.Check))
+					}
+					if res.Referenced && !res.Exact {
+						appendLog(fmt.Sprintf("[%s] |S|/α: %.3f | Разрыв: %d | Перекрытие с оптимумом: %.2f", res.Method, res.Quality.Ratio, res.Quality.Gap, res.Quality.Overlap))
+					}
+					if res.Interrupted {
+						appendLog(fmt.Sprintf("[%s] ⚠️ Расчёт остановлен (%s), лучшее найденное решение (оптимальность не доказана): %d вершин", res.Method, res.Status, len(res.Result)))
+					}
+				case experiment.Finished:
+					appendLog("")
+					switch {
+					case ev.Err == nil:
+						appendLog("✅ Расчёты завершены")
+					case errors.Is(ev.Err, context.Canceled):
+						appendLog("❌ Прервано пользователем, сохранены лучшие найденные решения")
+					default:
+						appendLog(fmt.Sprintf("❌ Ошибка: %v", ev.Err))
+						fyne.Do(func() {
+							dialog.ShowError(fmt.Errorf("расчёт не выполнен:\n%w", ev.Err), fyne.CurrentApp().Driver().AllWindows()[0])
+						})
 					}
-
-					state.Results = append(state.Results, &Result{
-						Graph:    g,
-						RunId:    i + 1,
-						Method:   string(methodName),
-						Time:     elapsed,
-						Result:   solution,
-						F1Factor: f1,
-					})
-
-					appendLog(fmt.Sprintf("[%s] Время: %d нс | F1: %.2f", methodName, elapsed, f1))
-
-					currentStep++
-					_ = progressVal.Set(currentStep / totalSteps)
 				}
-
-				appendLog("")
-			}
-
-			if ctx.Err() == nil {
-				appendLog("✅ Расчёты завершены")
-			} else {
-				appendLog("\n❌ Прервано пользователем")
-				state.Results = nil
+				_ = progressVal.Set(ev.Progress)
 			}
 		}()
 	}
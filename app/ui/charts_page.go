@@ -6,7 +6,10 @@ import (
 	"image"
 	"image/color"
 	"image/png"
+	"maps"
+	"math"
 	"os"
+	"slices"
 	"time"
 
 	"fyne.io/fyne/v2"
@@ -16,12 +19,21 @@ import (
 	"fyne.io/fyne/v2/widget"
 	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/plotutil"
 	"gonum.org/v1/plot/vg"
-	"gonum.org/v1/plot/vg/draw"
+
+	"graphmis/experiment"
+)
+
+const (
+	axisRun       = "ID запуска"
+	axisVertices  = "Число вершин n"
+	axisDensities = "Плотность p"
 )
 
 func NewChartsPage(state *AppState) (fyne.CanvasObject, func()) {
 	var updateFuncs []func()
+	axis := axisRun
 
 	saveImage := func(path string, img image.Image) error {
 		file, err := os.Create(path)
@@ -32,39 +44,30 @@ func NewChartsPage(state *AppState) (fyne.CanvasObject, func()) {
 		return png.Encode(file, img)
 	}
 
-	buildPlot := func(title, yLabel string, exactData, approxData plotter.XYs) image.Image {
+	buildPlot := func(title, xLabel, yLabel string, series []methodSeries) image.Image {
 		p := plot.New()
 		p.Title.Text = title
-		p.X.Label.Text = "ID запуска"
+		p.X.Label.Text = xLabel
 		p.Y.Label.Text = yLabel
 		p.BackgroundColor = color.White
 
-		if len(state.Results) > 2 {
-			exact, _ := plotter.NewLine(exactData)
-			exact.LineStyle.Dashes = []vg.Length{vg.Points(3), vg.Points(3)}
-			exact.Color = color.RGBA{0, 0, 255, 255}
-			exact.Width = vg.Points(1)
+		for i, s := range series {
+			if len(state.Results) > 2 {
+				line, _ := plotter.NewLine(s.data)
+				line.LineStyle.Dashes = plotutil.Dashes(i)
+				line.Color = plotutil.Color(i)
+				line.Width = vg.Points(1)
 
-			approx, _ := plotter.NewLine(approxData)
-			approx.LineStyle.Dashes = []vg.Length{vg.Points(3), vg.Points(1)}
-			approx.Color = color.RGBA{255, 0, 0, 255}
-			approx.Width = vg.Points(1)
+				p.Add(line)
+				p.Legend.Add(s.method, line)
+			} else {
+				scatter, _ := plotter.NewScatter(s.data)
+				scatter.Shape = plotutil.Shape(i)
+				scatter.Color = plotutil.Color(i)
 
-			p.Add(exact, approx)
-			p.Legend.Add("Метод Магу", exact)
-			p.Legend.Add("Жадный поиск", approx)
-		} else {
-			exact, _ := plotter.NewScatter(exactData)
-			exact.Shape = draw.RingGlyph{}
-			exact.Color = color.RGBA{0, 0, 255, 255}
-
-			approx, _ := plotter.NewScatter(approxData)
-			approx.Shape = draw.CrossGlyph{}
-			approx.Color = color.RGBA{255, 0, 0, 255}
-
-			p.Add(exact, approx)
-			p.Legend.Add("Метод Магу", exact)
-			p.Legend.Add("Жадный поиск", approx)
+				p.Add(scatter)
+				p.Legend.Add(s.method, scatter)
+			}
 		}
 
 		buf := new(bytes.Buffer)
@@ -75,7 +78,7 @@ func NewChartsPage(state *AppState) (fyne.CanvasObject, func()) {
 		return img
 	}
 
-	buildChartTab := func(title, yLabel string, getData func() (plotter.XYs, plotter.XYs), filenamePrefix string) fyne.CanvasObject {
+	buildChartTab := func(title string, xLabel func() string, yLabel string, collect func() []methodSeries, filenamePrefix string) fyne.CanvasObject {
 		img := canvas.NewImageFromImage(nil)
 		img.FillMode = canvas.ImageFillContain
 
@@ -96,73 +99,190 @@ func NewChartsPage(state *AppState) (fyne.CanvasObject, func()) {
 		})
 
 		updateFuncs = append(updateFuncs, func() {
-			exact, approx := getData()
-			img.Image = buildPlot(title, yLabel, exact, approx)
+			img.Image = buildPlot(title, xLabel(), yLabel, collect())
 			img.Refresh()
 		})
 
 		return container.NewBorder(nil, saveBtn, nil, nil, img)
 	}
 
-	getTimeData := func() (plotter.XYs, plotter.XYs) {
-		var exact, approx plotter.XYs
-		for _, res := range state.Results {
-			x := float64(res.RunId)
-			y := float64(res.Time)
-			switch res.Method {
-			case string(MaghoutMethod):
-				exact = append(exact, plotter.XY{X: x, Y: y})
-			case string(GreedySearchMethod):
-				approx = append(approx, plotter.XY{X: x, Y: y})
-			}
+	collectAxis := func(results []*experiment.Result, value func(res *experiment.Result) float64) []methodSeries {
+		switch axis {
+		case axisVertices:
+			return collectByCell(results, func(c experiment.Cell) float64 { return float64(c.Vertices) }, value)
+		case axisDensities:
+			return collectByCell(results, func(c experiment.Cell) float64 { return c.Density }, value)
 		}
-		return exact, approx
+		return collectSeries(results, value)
 	}
-
-	getF1Data := func() (plotter.XYs, plotter.XYs) {
-		var exact, approx plotter.XYs
-		for _, res := range state.Results {
-			x := float64(res.RunId)
-			y := res.F1Factor
-			switch res.Method {
-			case string(MaghoutMethod):
-				exact = append(exact, plotter.XY{X: x, Y: y})
-			case string(GreedySearchMethod):
-				approx = append(approx, plotter.XY{X: x, Y: y})
-			}
+	byAxis := func(value func(res *experiment.Result) float64) func() []methodSeries {
+		return func() []methodSeries {
+			return collectAxis(state.Results, value)
 		}
-		return exact, approx
 	}
-
-	getCardinalityData := func() (plotter.XYs, plotter.XYs) {
-		var exact, approx plotter.XYs
-		for _, res := range state.Results {
-			x := float64(res.RunId)
-			y := float64(len(res.Result))
-			switch res.Method {
-			case string(MaghoutMethod):
-				exact = append(exact, plotter.XY{X: x, Y: y})
-			case string(GreedySearchMethod):
-				approx = append(approx, plotter.XY{X: x, Y: y})
-			}
+	cardinality := func(res *experiment.Result) float64 { return float64(len(res.Result)) }
+	withBounds := func() []methodSeries {
+		lower, upper := boundResults(state.Results)
+		series := collectAxis(state.Results, cardinality)
+		series = append(series, collectAxis(lower, func(res *experiment.Result) float64 { return float64(res.Bounds.BestLower().Value) })...)
+		return append(series, collectAxis(upper, func(res *experiment.Result) float64 { return float64(res.Bounds.BestUpper().Value) })...)
+	}
+	axisLabel := func() string {
+		if axis == axisRun {
+			return axis
 		}
-		return exact, approx
+		return axis + " (среднее по ячейкам)"
+	}
+	fixedLabel := func(label string) func() string {
+		return func() string { return label }
 	}
 
 	now := time.Now().Format("2006-01-02T15-04-05")
 	tabs := container.NewAppTabs(
-		container.NewTabItem("Время выполнения", buildChartTab("Время выполнения", "Время (нс)", getTimeData, now+"_time")),
-		container.NewTabItem("F1-score", buildChartTab("F1-score", "F1", getF1Data, now+"_f1")),
-		container.NewTabItem("Мощность решений", buildChartTab("Мощность решений", "Размер множества", getCardinalityData, now+"_cardinality")),
+		container.NewTabItem("Время выполнения", buildChartTab("Время выполнения", axisLabel, "Время (нс)", byAxis(func(res *experiment.Result) float64 { return float64(res.Time) }), now+"_time")),
+		container.NewTabItem("F1-score", buildChartTab("F1-score", axisLabel, "F1", byAxis(func(res *experiment.Result) float64 { return res.F1Factor }), now+"_f1")),
+		container.NewTabItem("Мощность решений", buildChartTab("Мощность решений", axisLabel, "Размер множества", byAxis(cardinality), now+"_cardinality")),
+		container.NewTabItem("Оценки α", buildChartTab("Мощность решений и оценки α", axisLabel, "Размер множества", withBounds, now+"_bounds")),
+		container.NewTabItem("|S|/α", buildChartTab("Коэффициент аппроксимации |S|/α", axisLabel, "|S|/α", byAxis(referenced(func(res *experiment.Result) float64 { return res.Quality.Ratio })), now+"_ratio")),
+		container.NewTabItem("Разрыв", buildChartTab("Разрыв α - |S|", axisLabel, "Вершин", byAxis(referenced(func(res *experiment.Result) float64 { return float64(res.Quality.Gap) })), now+"_gap")),
+		container.NewTabItem("Перекрытие", buildChartTab("Перекрытие с ближайшим оптимумом", axisLabel, "Доля вершин оптимума", byAxis(referenced(func(res *experiment.Result) float64 { return res.Quality.Overlap })), now+"_overlap")),
+		container.NewTabItem("Сходимость", buildChartTab("Сходимость (последний запуск)", fixedLabel("Время (мс)"), "Размер множества", func() []methodSeries { return collectTraces(state.Results) }, now+"_convergence")),
 	)
 
-	initFunc := func() {
+	update := func() {
 		for _, f := range updateFuncs {
 			f()
 		}
+	}
+
+	axisSelect := widget.NewSelect([]string{axisRun, axisVertices, axisDensities}, func(selected string) {
+		axis = selected
+		update()
+	})
+	axisSelect.Selected = axisRun
+
+	initFunc := func() {
+		hasCells := slices.ContainsFunc(state.Results, func(res *experiment.Result) bool { return res.Cell != nil })
+		if hasCells {
+			axisSelect.Enable()
+		} else {
+			axis = axisRun
+			axisSelect.Selected = axisRun
+			axisSelect.Refresh()
+			axisSelect.Disable()
+		}
+
+		update()
 		state.NavigationState.NextButton.Disable()
 		state.NavigationState.BackButton.Enable()
 	}
 
-	return tabs, initFunc
+	top := container.NewHBox(widget.NewLabel("Ось X:"), axisSelect)
+	return container.NewBorder(top, nil, nil, nil, tabs), initFunc
+}
+
+type methodSeries struct {
+	method string
+	data   plotter.XYs
+}
+
+func collectSeries(results []*experiment.Result, value func(res *experiment.Result) float64) []methodSeries {
+	var series []methodSeries
+	index := make(map[string]int)
+	for _, res := range results {
+		i, ok := index[res.Method]
+		if !ok {
+			i = len(series)
+			index[res.Method] = i
+			series = append(series, methodSeries{method: res.Method})
+		}
+		if y := value(res); !math.IsNaN(y) {
+			series[i].data = append(series[i].data, plotter.XY{X: float64(res.RunId), Y: y})
+		}
+	}
+	return series
+}
+
+func boundResults(results []*experiment.Result) (lower, upper []*experiment.Result) {
+	seen := make(map[int]bool)
+	for _, res := range results {
+		if seen[res.RunId] {
+			continue
+		}
+		seen[res.RunId] = true
+
+		l, u := *res, *res
+		l.Method = "Нижняя оценка α"
+		u.Method = "Верхняя оценка α"
+		lower = append(lower, &l)
+		upper = append(upper, &u)
+	}
+	return lower, upper
+}
+
+func referenced(value func(res *experiment.Result) float64) func(res *experiment.Result) float64 {
+	return func(res *experiment.Result) float64 {
+		if !res.Referenced {
+			return math.NaN()
+		}
+		return value(res)
+	}
+}
+
+func collectByCell(results []*experiment.Result, key func(c experiment.Cell) float64, value func(res *experiment.Result) float64) []methodSeries {
+	type point struct {
+		sum   float64
+		count int
+	}
+
+	var methods []string
+	points := make(map[string]map[float64]*point)
+	for _, res := range results {
+		if res.Cell == nil || math.IsNaN(value(res)) {
+			continue
+		}
+		byKey, ok := points[res.Method]
+		if !ok {
+			byKey = make(map[float64]*point)
+			points[res.Method] = byKey
+			methods = append(methods, res.Method)
+		}
+		x := key(*res.Cell)
+		if byKey[x] == nil {
+			byKey[x] = &point{}
+		}
+		byKey[x].sum += value(res)
+		byKey[x].count++
+	}
+
+	series := make([]methodSeries, 0, len(methods))
+	for _, method := range methods {
+		s := methodSeries{method: method}
+		for _, x := range slices.Sorted(maps.Keys(points[method])) {
+			p := points[method][x]
+			s.data = append(s.data, plotter.XY{X: x, Y: p.sum / float64(p.count)})
+		}
+		series = append(series, s)
+	}
+	return series
+}
+
+func collectTraces(results []*experiment.Result) []methodSeries {
+	if len(results) == 0 {
+		return nil
+	}
+
+	lastRun := results[len(results)-1].RunId
+	var series []methodSeries
+	for _, res := range results {
+		if res.RunId != lastRun {
+			continue
+		}
+		s := methodSeries{method: res.Method}
+		for _, point := range res.Trace {
+			s.data = append(s.data, plotter.XY{X: float64(point.Elapsed.Microseconds()) / 1000, Y: float64(point.Size)})
+		}
+		series = append(series, s)
+	}
+	return series
 }
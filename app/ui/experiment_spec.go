@@ -0,0 +1,134 @@
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+
+	"graphmis/experiment"
+)
+
+var specExtensions = []string{".yaml", ".yml", ".toml", ".json"}
+
+func specFromState(state *AppState) (*experiment.Spec, error) {
+	spec := &experiment.Spec{Version: experiment.SpecVersion}
+
+	switch {
+	case state.Sweep != nil && state.GeneratorConfig != nil:
+		spec.Graphs = []experiment.GraphSource{{Sweep: experiment.SweepSpecOf(state.GeneratorConfig.GraphType, *state.Sweep)}}
+	case state.GeneratorConfig != nil && state.GraphPath == "":
+		spec.Graphs = []experiment.GraphSource{{Generator: experiment.GeneratorSpecOf(*state.GeneratorConfig)}}
+	case state.GraphPath != "":
+		spec.Graphs = []experiment.GraphSource{{Dot: state.GraphPath}}
+	default:
+		return nil, fmt.Errorf("граф не задан: сгенерируйте граф или загрузите его из файла")
+	}
+
+	for _, solver := range state.Solvers {
+		spec.Methods = append(spec.Methods, experiment.MethodSpecOf(solver))
+	}
+
+	if state.RunConfig != nil {
+		spec.Runs = state.RunConfig.RunsNumber
+		spec.FixedGraph = state.RunConfig.IsGraphFixed
+		spec.Seed = state.RunConfig.Seed
+		spec.Workers = state.RunConfig.Workers
+		spec.Pinned = state.RunConfig.Pinned
+	}
+
+	if err := spec.Validate(state.Registry); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+func applySpec(state *AppState, spec *experiment.Spec, baseDir string) (int, error) {
+	experiments, err := spec.Experiments(state.Registry, baseDir)
+	if err != nil {
+		return 0, err
+	}
+
+	exp := experiments[0]
+	g := exp.Graph
+	if g == nil && exp.Sweep != nil {
+		cell := exp.Sweep.Grid()[0]
+		g, err = experiment.GenerateGraph(exp.Generator.GraphType, cell.Vertices, cell.Vertices, cell.Density, exp.Generator.Seed)
+	} else if g == nil {
+		g, err = exp.Generator.Generate(exp.Generator.Seed)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	state.Graph = g
+	state.GraphPath = exp.Path
+	state.GeneratorConfig = exp.Generator
+	state.Sweep = exp.Sweep
+	state.Solvers = exp.Solvers
+	run := exp.Run
+	state.RunConfig = &run
+	state.Results = nil
+
+	return len(experiments), nil
+}
+
+func showLoadSpecDialog(state *AppState, onLoaded func()) {
+	window := fyne.CurrentApp().Driver().AllWindows()[0]
+
+	open := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+		if err != nil || reader == nil {
+			return
+		}
+		path := reader.URI().Path()
+		reader.Close()
+
+		spec, err := experiment.LoadSpec(path)
+		if err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+
+		count, err := applySpec(state, spec, filepath.Dir(path))
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("ошибка в описании эксперимента:\n%w", err), window)
+			return
+		}
+
+		onLoaded()
+		if count > 1 {
+			dialog.ShowInformation("Эксперимент загружен", fmt.Sprintf("В описании %d источников графов, в интерфейсе используется первый. Для запуска всех источников используйте graphmis run.", count), window)
+		}
+	}, window)
+	open.SetFilter(storage.NewExtensionFileFilter(specExtensions))
+	open.Show()
+}
+
+func showSaveSpecDialog(state *AppState) {
+	window := fyne.CurrentApp().Driver().AllWindows()[0]
+
+	spec, err := specFromState(state)
+	if err != nil {
+		dialog.ShowError(fmt.Errorf("невозможно сохранить эксперимент:\n%w", err), window)
+		return
+	}
+
+	save := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+		if err != nil || writer == nil {
+			return
+		}
+		path := writer.URI().Path()
+		writer.Close()
+
+		if err := experiment.SaveSpec(path, spec); err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		dialog.ShowInformation("Успех", "Описание эксперимента сохранено", window)
+	}, window)
+	save.SetFileName("experiment.yaml")
+	save.SetFilter(storage.NewExtensionFileFilter(specExtensions))
+	save.Show()
+}
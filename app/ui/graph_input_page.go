@@ -3,6 +3,8 @@ package ui
 import (
 	"fmt"
 	"image/color"
+	"strconv"
+	"strings"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
@@ -12,10 +14,14 @@ import (
 	"fyne.io/fyne/v2/widget"
 
 	"graphmis/app/utils"
+	"graphmis/experiment"
 	"graphmis/graph"
 )
 
 func NewGraphInputPage(state *AppState) (fyne.CanvasObject, func()) {
+	var heatmapButton *widget.Button
+	var heatmapInfo *widget.Label
+
 	initFunc := func() {
 		state.NavigationState.BackButton.Disable()
 		if state.Graph != nil {
@@ -23,6 +29,12 @@ func NewGraphInputPage(state *AppState) (fyne.CanvasObject, func()) {
 		} else {
 			state.NavigationState.NextButton.Disable()
 		}
+		if optimaOf(state) != nil {
+			heatmapButton.Enable()
+		} else {
+			heatmapButton.Disable()
+		}
+		heatmapInfo.SetText("")
 	}
 
 	title := canvas.NewText("Ввод графа", nil)
@@ -34,6 +46,9 @@ func NewGraphInputPage(state *AppState) (fyne.CanvasObject, func()) {
 	visualizeButton := widget.NewButton("Визуализировать", nil)
 	visualizeButton.Disable()
 
+	heatmapInfo = widget.NewLabel("")
+	heatmapInfo.Wrapping = fyne.TextWrapWord
+
 	visualizeButton.OnTapped = func() {
 		if state.Graph == nil {
 			return
@@ -54,10 +69,31 @@ func NewGraphInputPage(state *AppState) (fyne.CanvasObject, func()) {
 		}()
 	}
 
+	heatmapButton = widget.NewButton("Тепловая карта оптимумов", func() {
+		optima := optimaOf(state)
+		if optima == nil {
+			return
+		}
+
+		complete := ""
+		if !optima.Complete {
+			complete = " (перечень неполный)"
+		}
+		heatmapInfo.SetText(fmt.Sprintf("Оптимальных решений: %d%s, α = %d. Цвет вершины - доля оптимумов, содержащих её: от белого (0) до красного (1)", len(optima.Sets), complete, optima.Alpha))
+
+		go func() {
+			previewStack.Objects = []fyne.CanvasObject{utils.RenderHeatmapToFyneContainer(state.Graph, previewStack.Size(), optima.Frequency)}
+			previewStack.Refresh()
+		}()
+	})
+	heatmapButton.Disable()
+
 	resetVisualization := func() {
 		previewStack.Objects = nil
 		previewStack.Refresh()
 		visualizeButton.Enable()
+		heatmapButton.Disable()
+		heatmapInfo.SetText("")
 	}
 
 	minVerts := widget.NewEntry()
@@ -69,6 +105,9 @@ func NewGraphInputPage(state *AppState) (fyne.CanvasObject, func()) {
 	density := widget.NewEntry()
 	density.SetPlaceHolder("Плотность графа [0.0 ... 1.0]")
 
+	seedEntry := widget.NewEntry()
+	seedEntry.SetPlaceHolder("Зерно генератора (пусто - случайное)")
+
 	graphTypeSelector := widget.NewSelect([]string{"Неориентированный", "Ориентированный"}, nil)
 	graphTypeSelector.SetSelected("Неориентированный")
 
@@ -76,8 +115,9 @@ func NewGraphInputPage(state *AppState) (fyne.CanvasObject, func()) {
 		minV, err1 := utils.ParseUint(minVerts.Text)
 		maxV, err2 := utils.ParseUint(maxVerts.Text)
 		p, err3 := utils.ParseFloatCoefficient(density.Text)
+		seed, err4 := utils.ParseSeed(seedEntry.Text)
 
-		if err := utils.FindFirstError(err1, err2, err3); err != nil {
+		if err := utils.FindFirstError(err1, err2, err3, err4); err != nil {
 			dialog.ShowError(fmt.Errorf("неверные параметры генерации: %w", err), fyne.CurrentApp().Driver().AllWindows()[0])
 			return
 		}
@@ -94,21 +134,25 @@ func NewGraphInputPage(state *AppState) (fyne.CanvasObject, func()) {
 			gt = graph.Undirected
 		}
 
-		g := utils.GenerateGraph(gt, minV, maxV, p)
-		if g == nil {
-			dialog.ShowError(fmt.Errorf("ошибка генерации графа"), fyne.CurrentApp().Driver().AllWindows()[0])
+		g, err := experiment.GenerateGraph(gt, minV, maxV, p, seed)
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("ошибка генерации графа: %w", err), fyne.CurrentApp().Driver().AllWindows()[0])
 			return
 		}
 
 		state.Graph = g
+		state.GraphPath = ""
+		state.Sweep = nil
 		state.RunConfig = nil
 		state.Results = nil
-		state.GeneratorConfig = &GeneratorConfig{
+		state.GeneratorConfig = &experiment.GeneratorConfig{
 			MinVerticesNumber: minV,
 			MaxVerticesNumber: maxV,
 			GraphDensity:      p,
 			GraphType:         gt,
+			Seed:              seed,
 		}
+		seedEntry.SetText(strconv.FormatInt(seed, 10))
 		resetVisualization()
 		state.NavigationState.NextButton.Enable()
 		visualizeButton.Enable()
@@ -128,8 +172,10 @@ func NewGraphInputPage(state *AppState) (fyne.CanvasObject, func()) {
 			}
 
 			state.Graph = g
+			state.GraphPath = reader.URI().Path()
 			state.GeneratorConfig = nil
-			state.RunConfig = &RunConfig{IsGraphFixed: true}
+			state.Sweep = nil
+			state.RunConfig = &experiment.RunConfig{IsGraphFixed: true}
 			state.Results = nil
 			resetVisualization()
 			state.NavigationState.NextButton.Enable()
@@ -137,22 +183,108 @@ func NewGraphInputPage(state *AppState) (fyne.CanvasObject, func()) {
 		}, fyne.CurrentApp().Driver().AllWindows()[0])
 	})
 
+	sweepVerts := widget.NewEntry()
+	sweepVerts.SetPlaceHolder("Значения n: 10, 20, 30 или 10..40:5")
+
+	sweepDensities := widget.NewEntry()
+	sweepDensities.SetPlaceHolder("Значения p: 0.1, 0.3 или 0.1..0.5:0.2")
+
+	sweepButton := widget.NewButton("Задать серию", func() {
+		vertices, err1 := utils.ParseIntValues(sweepVerts.Text)
+		densities, err2 := utils.ParseFloatValues(sweepDensities.Text)
+		seed, err3 := utils.ParseSeed(seedEntry.Text)
+
+		if err := utils.FindFirstError(err1, err2, err3); err != nil {
+			dialog.ShowError(fmt.Errorf("неверные параметры серии: %w", err), fyne.CurrentApp().Driver().AllWindows()[0])
+			return
+		}
+
+		var gt graph.GraphType
+		if graphTypeSelector.Selected == "Ориентированный" {
+			gt = graph.Directed
+		} else {
+			gt = graph.Undirected
+		}
+
+		sweep := &experiment.Sweep{Vertices: vertices, Densities: densities}
+		first := sweep.Grid()[0]
+
+		g, err := experiment.GenerateGraph(gt, first.Vertices, first.Vertices, first.Density, seed)
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("ошибка генерации графа: %w", err), fyne.CurrentApp().Driver().AllWindows()[0])
+			return
+		}
+
+		state.Graph = g
+		state.GraphPath = ""
+		state.Sweep = sweep
+		state.RunConfig = nil
+		state.Results = nil
+		state.GeneratorConfig = &experiment.GeneratorConfig{
+			MinVerticesNumber: first.Vertices,
+			MaxVerticesNumber: first.Vertices,
+			GraphDensity:      first.Density,
+			GraphType:         gt,
+			Seed:              seed,
+		}
+		seedEntry.SetText(strconv.FormatInt(seed, 10))
+		resetVisualization()
+		state.NavigationState.NextButton.Enable()
+		dialog.ShowInformation("Серия задана", fmt.Sprintf("Ячеек: %d. В окне предпросмотра показан граф первой ячейки (%v).", len(sweep.Grid()), first), fyne.CurrentApp().Driver().AllWindows()[0])
+	})
+
+	fillGenerator := func() {
+		if state.GeneratorConfig == nil {
+			return
+		}
+		minVerts.SetText(strconv.Itoa(state.GeneratorConfig.MinVerticesNumber))
+		maxVerts.SetText(strconv.Itoa(state.GeneratorConfig.MaxVerticesNumber))
+		density.SetText(strconv.FormatFloat(state.GeneratorConfig.GraphDensity, 'g', -1, 64))
+		seedEntry.SetText(strconv.FormatInt(state.GeneratorConfig.Seed, 10))
+		if state.GeneratorConfig.GraphType == graph.Directed {
+			graphTypeSelector.SetSelected("Ориентированный")
+		} else {
+			graphTypeSelector.SetSelected("Неориентированный")
+		}
+
+		if state.Sweep != nil {
+			sweepVerts.SetText(joinValues(state.Sweep.Vertices))
+			sweepDensities.SetText(joinValues(state.Sweep.Densities))
+		}
+	}
+
+	loadSpecButton := widget.NewButton("Загрузить эксперимент", func() {
+		showLoadSpecDialog(state, func() {
+			fillGenerator()
+			resetVisualization()
+			state.NavigationState.NextButton.Enable()
+		})
+	})
+
 	label1 := widget.NewLabel("Загрузка графа из файла")
 	label1.Alignment = fyne.TextAlignCenter
 	label2 := widget.NewLabel("Случайная генерация графа")
 	label2.Alignment = fyne.TextAlignCenter
+	label3 := widget.NewLabel("Серия графов (n × p)")
+	label3.Alignment = fyne.TextAlignCenter
 
 	leftContent := container.NewVBox(
 		container.NewPadded(title),
 		container.NewPadded(label1),
 		container.NewPadded(importButton),
+		container.NewPadded(loadSpecButton),
 		layout.NewSpacer(),
 		container.NewPadded(label2),
 		container.NewPadded(minVerts),
 		container.NewPadded(maxVerts),
 		container.NewPadded(density),
+		container.NewPadded(seedEntry),
 		container.NewPadded(graphTypeSelector),
 		container.NewPadded(genButton),
+		container.NewPadded(label3),
+		container.NewPadded(sweepVerts),
+		container.NewPadded(sweepDensities),
+		container.NewPadded(sweepButton),
 	)
 
 	leftSide := container.NewVBox(
@@ -166,9 +298,29 @@ func NewGraphInputPage(state *AppState) (fyne.CanvasObject, func()) {
 	rightContent := container.NewStack(bg, previewStack)
 	rightContent.Resize(fyne.NewSize(800, 800))
 
-	rightSide := container.NewBorder(nil, visualizeButton, nil, nil, container.NewPadded(rightContent))
+	rightSide := container.NewBorder(nil, container.NewVBox(heatmapInfo, visualizeButton, heatmapButton), nil, nil, container.NewPadded(rightContent))
 	split := container.NewHSplit(leftSide, rightSide)
 	split.Offset = 0.15
 
 	return container.NewBorder(nil, nil, nil, nil, split), initFunc
 }
+
+func optimaOf(state *AppState) *graph.MaximumSets[string] {
+	if state.Graph == nil {
+		return nil
+	}
+	for _, res := range state.Results {
+		if res.Graph == state.Graph && res.Optima != nil {
+			return res.Optima
+		}
+	}
+	return nil
+}
+
+func joinValues[T int | float64](values []T) string {
+	parts := make([]string, len(values))
+	for i, v := range values {
+		parts[i] = fmt.Sprint(v)
+	}
+	return strings.Join(parts, ", ")
+}
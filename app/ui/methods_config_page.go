@@ -1,6 +1,11 @@
 package ui
 
 import (
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
@@ -9,14 +14,75 @@ import (
 	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
 
-	"graphmis/app/utils"
+	"graphmis/graph"
 )
 
+type methodForm struct {
+	descriptor graph.SolverDescriptor[string]
+	enabled    *widget.Check
+	entries    map[string]*widget.Entry
+	checks     map[string]*widget.Check
+}
+
+func (f *methodForm) setEnabled(enabled bool) {
+	for _, entry := range f.entries {
+		if enabled {
+			entry.Enable()
+		} else {
+			entry.Disable()
+		}
+	}
+	for _, check := range f.checks {
+		if enabled {
+			check.Enable()
+		} else {
+			check.Disable()
+		}
+	}
+}
+
+func (f *methodForm) params() graph.Params {
+	params := make(graph.Params, len(f.descriptor.Params))
+	for name, entry := range f.entries {
+		params[name] = entry.Text
+	}
+	for name, check := range f.checks {
+		params[name] = check.Checked
+	}
+	return params
+}
+
+func (f *methodForm) load(solver graph.Solver[string]) {
+	config := solver.Config()
+	for name, entry := range f.entries {
+		if value, ok := config[name]; ok {
+			entry.SetText(formatParam(value))
+		}
+	}
+	for name, check := range f.checks {
+		if value, ok := config[name].(bool); ok {
+			check.SetChecked(value)
+		}
+	}
+}
+
 func NewMethodConfigPage(state *AppState) (fyne.CanvasObject, func()) {
+	var forms []*methodForm
+
 	initFunc := func() {
 		state.NavigationState.BackButton.Enable()
 
-		if len(state.MethodConfigs) == 0 {
+		if len(state.Solvers) > 0 {
+			for _, form := range forms {
+				idx := slices.IndexFunc(state.Solvers, func(s graph.Solver[string]) bool { return s.Name() == form.descriptor.Name })
+				form.enabled.SetChecked(idx >= 0)
+				if idx >= 0 {
+					form.load(state.Solvers[idx])
+				}
+			}
+		}
+
+		if len(state.Solvers) == 0 {
 			state.NavigationState.NextButton.Disable()
 		} else {
 			state.NavigationState.NextButton.Enable()
@@ -27,42 +93,69 @@ func NewMethodConfigPage(state *AppState) (fyne.CanvasObject, func()) {
 	title.TextStyle = fyne.TextStyle{Bold: true}
 	title.Alignment = fyne.TextAlignCenter
 
-	maghoutLabel := widget.NewLabel("Метод Магу")
-	maghoutLabel.Alignment = fyne.TextAlignCenter
-	maghoutLabel.TextStyle = fyne.TextStyle{Bold: true}
+	var sections []fyne.CanvasObject
+	hasExact, hasApprox := false, false
 
-	parallelismDepthEntry := widget.NewEntry()
-	parallelismDepthEntry.SetPlaceHolder("Глубина параллельности")
+	for _, d := range state.Registry.Descriptors() {
+		form := &methodForm{
+			descriptor: d,
+			entries:    make(map[string]*widget.Entry),
+			checks:     make(map[string]*widget.Check),
+		}
+		forms = append(forms, form)
+
+		methodLabel := widget.NewLabel(d.Name)
+		methodLabel.TextStyle = fyne.TextStyle{Bold: true}
+
+		form.enabled = widget.NewCheck("", form.setEnabled)
+		section := []fyne.CanvasObject{container.NewHBox(form.enabled, methodLabel)}
+
+		for _, spec := range d.Params {
+			if spec.Kind == graph.BoolParam {
+				check := widget.NewCheck(spec.Label, nil)
+				check.SetChecked(spec.Default == true)
+				form.checks[spec.Name] = check
+				section = append(section, container.NewPadded(check))
+				continue
+			}
+
+			entry := widget.NewEntry()
+			entry.SetPlaceHolder(spec.Label)
+			entry.SetText(formatParam(spec.Default))
+			form.entries[spec.Name] = entry
+			section = append(section, container.NewPadded(widget.NewLabel(spec.Label)), container.NewPadded(entry))
+		}
 
-	hybridLabel := widget.NewLabel("Жадный поиск")
-	hybridLabel.Alignment = fyne.TextAlignCenter
-	hybridLabel.TextStyle = fyne.TextStyle{Bold: true}
+		enabled := (d.Exact && !hasExact) || (!d.Exact && !hasApprox)
+		hasExact = hasExact || d.Exact
+		hasApprox = hasApprox || !d.Exact
+		form.enabled.SetChecked(enabled)
+		form.setEnabled(enabled)
 
-	localSearchIterationsEntry := widget.NewEntry()
-	localSearchIterationsEntry.SetPlaceHolder("Итерации локального поиска")
+		sections = append(sections, container.NewVBox(section...))
+	}
 
 	saveButton := widget.NewButtonWithIcon("Сохранить", theme.ConfirmIcon(), func() {
-		parallelDepth, err := utils.ParseUint(parallelismDepthEntry.Text)
-		if err != nil {
-			dialog.ShowError(err, fyne.CurrentApp().Driver().AllWindows()[0])
-			return
+		var solvers []graph.Solver[string]
+		for _, form := range forms {
+			if !form.enabled.Checked {
+				continue
+			}
+
+			solver, err := state.Registry.New(form.descriptor.Name, form.params())
+			if err != nil {
+				dialog.ShowError(err, fyne.CurrentApp().Driver().AllWindows()[0])
+				return
+			}
+			solvers = append(solvers, solver)
 		}
 
-		mCfg := &MaghoutConfig{ParallelDepth: parallelDepth}
-
-		localIters, err := utils.ParseUint(localSearchIterationsEntry.Text)
-		if err != nil {
-			dialog.ShowError(err, fyne.CurrentApp().Driver().AllWindows()[0])
+		if len(solvers) == 0 {
+			dialog.ShowError(fmt.Errorf("не выбран ни один метод"), fyne.CurrentApp().Driver().AllWindows()[0])
 			return
 		}
 
-		// mCfg := &MaghoutConfig{}
-		hCfg := &GreedySearchConfig{Iterations: localIters}
-
-		state.MethodConfigs = nil
-		// state.MethodConfigs = append(state.MethodConfigs, mCfg, hCfg)
-		state.MethodConfigs = append(state.MethodConfigs, mCfg, hCfg)
-
+		state.Solvers = solvers
 		state.NavigationState.NextButton.Enable()
 	})
 
@@ -70,11 +163,7 @@ func NewMethodConfigPage(state *AppState) (fyne.CanvasObject, func()) {
 		layout.NewSpacer(),
 		container.NewPadded(title),
 		layout.NewSpacer(),
-		container.NewPadded(maghoutLabel),
-		container.NewPadded(parallelismDepthEntry),
-		layout.NewSpacer(),
-		container.NewPadded(hybridLabel),
-		container.NewPadded(localSearchIterationsEntry),
+		container.NewGridWithColumns(2, sections...),
 		layout.NewSpacer(),
 		container.NewPadded(saveButton),
 		layout.NewSpacer(),
@@ -84,5 +173,14 @@ func NewMethodConfigPage(state *AppState) (fyne.CanvasObject, func()) {
 		form,
 	))
 
-	return container.NewBorder(nil, nil, nil, nil, container.NewPadded(centered)), initFunc
+	return container.NewBorder(nil, nil, nil, nil, container.NewVScroll(container.NewPadded(centered))), initFunc
+}
+
+func formatParam(value any) string {
+	switch v := value.(type) {
+	case time.Duration:
+		return strconv.FormatInt(v.Milliseconds(), 10)
+	default:
+		return fmt.Sprint(v)
+	}
 }
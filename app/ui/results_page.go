@@ -1,10 +1,11 @@
 package ui
 
 import (
-	"encoding/csv"
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -14,11 +15,14 @@ import (
 	"fyne.io/fyne/v2/data/binding"
 	"fyne.io/fyne/v2/dialog"
 	"fyne.io/fyne/v2/widget"
+
+	"graphmis/experiment"
+	"graphmis/graph"
 )
 
 func NewResultsPage(state *AppState) (fyne.CanvasObject, func()) {
-	exactResults := binding.NewUntypedList()
-	approxResults := binding.NewUntypedList()
+	var methods []string
+	methodResults := make(map[string]binding.UntypedList)
 
 	saveBtn := widget.NewButton("Сохранить в CSV", func() {
 		dialog.ShowFolderOpen(func(uri fyne.ListableURI, err error) {
@@ -40,88 +44,272 @@ func NewResultsPage(state *AppState) (fyne.CanvasObject, func()) {
 				}
 				defer file.Close()
 
-				writer := csv.NewWriter(file)
-				defer writer.Flush()
-
-				writer.Write([]string{"ID", "Время (нс)", "F1-score", "Мощность"})
-
-				length := results.Length()
-				for i := range length {
+				var rows []*experiment.Result
+				for i := range results.Length() {
 					item, _ := results.GetValue(i)
-					res := item.(*Result)
-
-					record := []string{
-						strconv.Itoa(res.RunId),
-						strconv.FormatInt(res.Time, 10),
-						fmt.Sprintf("%.2f", res.F1Factor),
-						strconv.Itoa(len(res.Result)),
-					}
-					writer.Write(record)
+					rows = append(rows, item.(*experiment.Result))
+				}
+				return experiment.WriteCSV(file, rows)
+			}
+
+			timestamp := time.Now().Format("2006-01-02T15-04-05")
+			for _, method := range methods {
+				if err := save(fmt.Sprintf("%s_%s_results.csv", timestamp, methodFileName(method)), methodResults[method]); err != nil {
+					dialog.ShowError(err, fyne.CurrentApp().Driver().AllWindows()[0])
+					return
 				}
-				return nil
 			}
 
-			if err := save(fmt.Sprintf("%s_exact_results.csv", time.Now().Format("2006-01-02T15-04-05")), exactResults); err != nil {
+			if err := saveSummary(filepath.Join(dir, timestamp+"_summary.csv"), experiment.Summaries(state.Results)); err != nil {
 				dialog.ShowError(err, fyne.CurrentApp().Driver().AllWindows()[0])
 				return
 			}
-			if err := save(fmt.Sprintf("%s_approx_results.csv", time.Now().Format("2006-01-02T15-04-05")), approxResults); err != nil {
+			if err := saveComparisons(filepath.Join(dir, timestamp+"_tests.csv"), experiment.Compare(state.Results)); err != nil {
 				dialog.ShowError(err, fyne.CurrentApp().Driver().AllWindows()[0])
 				return
 			}
 		}, fyne.CurrentApp().Driver().AllWindows()[0])
 	})
 
-	exactHeader := widget.NewLabel("Метод Магу")
-	exactHeader.Alignment = fyne.TextAlignCenter
-	exactHeader.TextStyle = fyne.TextStyle{Bold: true}
+	replay := func(res *experiment.Result) {
+		solver, err := state.Registry.New(res.Method, res.Config)
+		if err != nil {
+			dialog.ShowError(err, fyne.CurrentApp().Driver().AllWindows()[0])
+			return
+		}
 
-	approxHeader := widget.NewLabel("Жадный поиск")
-	approxHeader.Alignment = fyne.TextAlignCenter
-	approxHeader.TextStyle = fyne.TextStyle{Bold: true}
+		go func() {
+			ctx, stop := graph.WithLimits(context.Background(), solver.Limits())
+			defer stop()
+			solution, stats := solver.Solve(ctx, res.Graph, graph.WithSeed(res.Seed))
 
-	left := container.NewBorder(
-		exactHeader,
-		nil, nil, nil,
-		buildVirtualResultsList(exactResults),
-	)
+			same := "нет"
+			if slices.Equal(slices.Sorted(slices.Values(solution.Vertices)), slices.Sorted(slices.Values(res.Result))) {
+				same = "да"
+			}
 
-	right := container.NewBorder(
-		approxHeader,
-		nil, nil, nil,
-		buildVirtualResultsList(approxResults),
-	)
+			dialog.ShowInformation("Повтор запуска", fmt.Sprintf(
+				"%s, запуск #%d, зерно %d\nИсходно: мощность %d, вес %g (%s)\nПовтор: мощность %d, вес %g (%s)\nРешения совпадают: %s",
+				res.Method, res.RunId, res.Seed,
+				len(res.Result), res.Weight, res.StatusLabel(),
+				len(solution.Vertices), solution.Weight, stats.Status,
+				same,
+			), fyne.CurrentApp().Driver().AllWindows()[0])
+		}()
+	}
 
-	split := container.NewHSplit(left, right)
-	split.Offset = 0.5
+	tabs := container.NewAppTabs()
 
 	initFunc := func() {
 		state.NavigationState.NextButton.Enable()
 		state.NavigationState.BackButton.Enable()
 
-		exactResults.Set([]any{})
-		approxResults.Set([]any{})
+		methods = nil
+		clear(methodResults)
 
 		for _, res := range state.Results {
-			method := strings.TrimSpace(res.Method)
-			switch method {
-			case string(MaghoutMethod):
-				exactResults.Append(res)
-			case string(GreedySearchMethod):
-				approxResults.Append(res)
+			results, ok := methodResults[res.Method]
+			if !ok {
+				results = binding.NewUntypedList()
+				methodResults[res.Method] = results
+				methods = append(methods, res.Method)
 			}
+			results.Append(res)
+		}
+
+		items := make([]*container.TabItem, 0, len(methods))
+		for _, method := range methods {
+			items = append(items, container.NewTabItem(method, buildVirtualResultsList(methodResults[method], replay)))
 		}
+		items = append(items, container.NewTabItem("Сводка", buildSummaryTable(experiment.Summaries(state.Results))))
+		items = append(items, container.NewTabItem("Сравнение", buildComparisonTable(experiment.Compare(state.Results))))
+		tabs.SetItems(items)
+	}
+
+	return container.NewBorder(nil, saveBtn, nil, nil, tabs), initFunc
+}
+
+func saveSummary(path string, summaries []experiment.MethodSummary) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
 	}
+	defer file.Close()
 
-	return container.NewBorder(nil, saveBtn, nil, nil, split), initFunc
+	return experiment.WriteSummaryCSV(file, summaries)
 }
 
-func buildVirtualResultsList(results binding.UntypedList) fyne.CanvasObject {
-	headers := container.NewGridWithColumns(5,
+func buildSummaryTable(summaries []experiment.MethodSummary) fyne.CanvasObject {
+	headers := []string{"Ячейка", "Метод", "Запусков", "Время (нс)", "Медиана времени", "Ст. откл. времени", "Мин–макс времени", "Мощность", "Медиана мощности", "Ст. откл. мощности", "Мин–макс мощности", "|S|/α", "До верхней оценки α", "Доля оптимальных", "Некорректных", "Прерванных"}
+
+	withCI := func(s experiment.Summary, precision int) string {
+		return fmt.Sprintf("%.*f [%.*f; %.*f]", precision, s.Mean, precision, s.CILow, precision, s.CIHigh)
+	}
+
+	cell := func(s experiment.MethodSummary, col int) string {
+		if col >= 3 && col <= 13 && s.Time.Count == 0 {
+			return "—"
+		}
+		switch col {
+		case 0:
+			if s.Cell == nil {
+				return "—"
+			}
+			return s.Cell.String()
+		case 1:
+			return s.Method
+		case 2:
+			return strconv.Itoa(s.Runs)
+		case 3:
+			return withCI(s.Time, 0)
+		case 4:
+			return fmt.Sprintf("%.0f", s.Time.Median)
+		case 5:
+			return fmt.Sprintf("%.0f", s.Time.StdDev)
+		case 6:
+			return fmt.Sprintf("%.0f–%.0f", s.Time.Min, s.Time.Max)
+		case 7:
+			return withCI(s.Cardinality, 2)
+		case 8:
+			return fmt.Sprintf("%g", s.Cardinality.Median)
+		case 9:
+			return fmt.Sprintf("%.2f", s.Cardinality.StdDev)
+		case 10:
+			return fmt.Sprintf("%g–%g", s.Cardinality.Min, s.Cardinality.Max)
+		case 11:
+			if s.Compared == 0 {
+				return "нет эталона"
+			}
+			return withCI(s.Ratio, 3)
+		case 12:
+			return withCI(s.BoundGap, 2)
+		case 13:
+			if s.Compared == 0 {
+				return "нет эталона"
+			}
+			return fmt.Sprintf("%.0f%% (%d)", s.OptimalShare*100, s.Compared)
+		case 14:
+			return strconv.Itoa(s.Invalid)
+		case 15:
+			return strconv.Itoa(s.Interrupted)
+		}
+		return ""
+	}
+
+	table := widget.NewTable(
+		func() (int, int) {
+			return len(summaries) + 1, len(headers)
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel("")
+		},
+		func(id widget.TableCellID, item fyne.CanvasObject) {
+			label := item.(*widget.Label)
+			if id.Row == 0 {
+				label.TextStyle = fyne.TextStyle{Bold: true}
+				label.SetText(headers[id.Col])
+				return
+			}
+			label.TextStyle = fyne.TextStyle{}
+			label.SetText(cell(summaries[id.Row-1], id.Col))
+		},
+	)
+	for col, width := range []float32{140, 240, 90, 260, 150, 150, 180, 200, 150, 150, 150, 200, 200, 150, 130, 130} {
+		table.SetColumnWidth(col, width)
+	}
+	return table
+}
+
+func saveComparisons(path string, comparisons []experiment.Comparison) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return experiment.WriteComparisonCSV(file, comparisons)
+}
+
+func buildComparisonTable(comparisons []experiment.Comparison) fyne.CanvasObject {
+	headers := []string{"Ячейка", "Метрика", "Метод A", "Метод B", "Пар", "Средняя разность A-B", "Уилкоксон: p", "Уилкоксон: r", "Знаков: p", "Знаков: доля", "t-тест: p", "t-тест: d"}
+
+	cell := func(c experiment.Comparison, col int) string {
+		switch col {
+		case 0:
+			if c.Cell == nil {
+				return "—"
+			}
+			return c.Cell.String()
+		case 1:
+			return c.Metric
+		case 2:
+			return c.A
+		case 3:
+			return c.B
+		case 4:
+			return strconv.Itoa(c.Pairs)
+		case 5:
+			return fmt.Sprintf("%.3g", c.MeanDiff)
+		}
+
+		test := c.Tests[(col-6)/2]
+		if col%2 == 0 {
+			return formatPValue(test.PValue)
+		}
+		return fmt.Sprintf("%.3f", test.EffectSize)
+	}
+
+	table := widget.NewTable(
+		func() (int, int) {
+			return len(comparisons) + 1, len(headers)
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel("")
+		},
+		func(id widget.TableCellID, item fyne.CanvasObject) {
+			label := item.(*widget.Label)
+			if id.Row == 0 {
+				label.TextStyle = fyne.TextStyle{Bold: true}
+				label.SetText(headers[id.Col])
+				return
+			}
+			label.TextStyle = fyne.TextStyle{}
+			label.SetText(cell(comparisons[id.Row-1], id.Col))
+		},
+	)
+	for col, width := range []float32{140, 120, 240, 240, 60, 180, 130, 120, 110, 120, 110, 100} {
+		table.SetColumnWidth(col, width)
+	}
+
+	note := widget.NewLabel("Парные тесты по запускам на одних и тех же графах; * — различие значимо на уровне 0.05")
+	return container.NewBorder(note, nil, nil, nil, table)
+}
+
+func formatPValue(p float64) string {
+	text := fmt.Sprintf("%.4f", p)
+	if p < 0.05 {
+		text += " *"
+	}
+	return text
+}
+
+func methodFileName(method string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(method)), " ", "_")
+}
+
+func buildVirtualResultsList(results binding.UntypedList, replay func(res *experiment.Result)) fyne.CanvasObject {
+	headers := container.NewGridWithColumns(13,
 		container.NewCenter(widget.NewLabel("ID")),
 		container.NewCenter(widget.NewLabel("Время (нс)")),
 		container.NewCenter(widget.NewLabel("F1-score")),
 		container.NewCenter(widget.NewLabel("Мощность")),
+		container.NewCenter(widget.NewLabel("Вес")),
+		container.NewCenter(widget.NewLabel("|S|/α")),
+		container.NewCenter(widget.NewLabel("Разрыв")),
+		container.NewCenter(widget.NewLabel("Перекрытие")),
+		container.NewCenter(widget.NewLabel("До оценки α")),
+		container.NewCenter(widget.NewLabel("Статус")),
+		container.NewCenter(widget.NewLabel("Проверка")),
 	)
 
 	list := widget.NewList(
@@ -129,30 +317,61 @@ func buildVirtualResultsList(results binding.UntypedList) fyne.CanvasObject {
 			return results.Length()
 		},
 		func() fyne.CanvasObject {
-			return container.NewGridWithColumns(5,
+			return container.NewGridWithColumns(13,
+				container.NewCenter(widget.NewLabel("")),
+				container.NewCenter(widget.NewLabel("")),
+				container.NewCenter(widget.NewLabel("")),
+				container.NewCenter(widget.NewLabel("")),
+				container.NewCenter(widget.NewLabel("")),
+				container.NewCenter(widget.NewLabel("")),
+				container.NewCenter(widget.NewLabel("")),
 				container.NewCenter(widget.NewLabel("")),
 				container.NewCenter(widget.NewLabel("")),
 				container.NewCenter(widget.NewLabel("")),
 				container.NewCenter(widget.NewLabel("")),
 				container.NewCenter(widget.NewButton("Сохранить", nil)),
+				container.NewCenter(widget.NewButton("Повторить", nil)),
 			)
 		},
 		func(id widget.ListItemID, item fyne.CanvasObject) {
 			val, _ := results.GetValue(id)
-			res := val.(*Result)
+			res := val.(*experiment.Result)
 
 			row, _ := item.(*fyne.Container)
-			if row == nil || len(row.Objects) < 5 {
+			if row == nil || len(row.Objects) < 13 {
 				return
 			}
 
-			row.Objects[0].(*fyne.Container).Objects[0] = widget.NewLabel(strconv.Itoa(res.RunId))
+			runId := strconv.Itoa(res.RunId)
+			if res.Cell != nil {
+				runId = fmt.Sprintf("%d (%v)", res.RunId, *res.Cell)
+			}
+			row.Objects[0].(*fyne.Container).Objects[0] = widget.NewLabel(runId)
 			row.Objects[1].(*fyne.Container).Objects[0] = widget.NewLabel(strconv.FormatInt(res.Time, 10))
-			row.Objects[2].(*fyne.Container).Objects[0] = widget.NewLabel(fmt.Sprintf("%.2f", res.F1Factor))
+			row.Objects[2].(*fyne.Container).Objects[0] = widget.NewLabel(res.F1Label())
 			row.Objects[3].(*fyne.Container).Objects[0] = widget.NewLabel(strconv.Itoa(len(res.Result)))
+			row.Objects[4].(*fyne.Container).Objects[0] = widget.NewLabel(strconv.FormatFloat(res.Weight, 'f', -1, 64))
+			ratio, gap, overlap := "—", "—", "—"
+			if res.Referenced {
+				ratio = fmt.Sprintf("%.3f", res.Quality.Ratio)
+				gap = strconv.Itoa(res.Quality.Gap)
+				overlap = fmt.Sprintf("%.2f", res.Quality.Overlap)
+			}
+			row.Objects[5].(*fyne.Container).Objects[0] = widget.NewLabel(ratio)
+			row.Objects[6].(*fyne.Container).Objects[0] = widget.NewLabel(gap)
+			row.Objects[7].(*fyne.Container).Objects[0] = widget.NewLabel(overlap)
+			row.Objects[8].(*fyne.Container).Objects[0] = widget.NewLabel(fmt.Sprintf("%d (α ≤ %d)", res.BoundGap, res.Bounds.BestUpper().Value))
+			row.Objects[9].(*fyne.Container).Objects[0] = widget.NewLabel(res.StatusLabel())
+
+			check := widget.NewLabel("✓")
+			if !res.Check.Valid() {
+				check = widget.NewLabel(fmt.Sprintf("✗ нарушений: %d", len(res.Check.Violations)))
+				check.Importance = widget.DangerImportance
+			}
+			row.Objects[10].(*fyne.Container).Objects[0] = check
 
-			btn := row.Objects[4].(*fyne.Container).Objects[0].(*widget.Button)
-			btn.OnTapped = func(r *Result) func() {
+			btn := row.Objects[11].(*fyne.Container).Objects[0].(*widget.Button)
+			btn.OnTapped = func(r *experiment.Result) func() {
 				return func() {
 					dialog.ShowFolderOpen(func(uri fyne.ListableURI, err error) {
 						if err != nil || uri == nil {
@@ -163,13 +382,7 @@ func buildVirtualResultsList(results binding.UntypedList) fyne.CanvasObject {
 						}
 						dir := uri.Path()
 
-						var accuracyPrefix string
-						if res.Method == "Метод Магу" {
-							accuracyPrefix = "exact"
-						} else {
-							accuracyPrefix = "approx"
-						}
-						filenameBase := fmt.Sprintf("%s_result_run%d", accuracyPrefix, r.RunId)
+						filenameBase := fmt.Sprintf("%s_result_run%d", methodFileName(r.Method), r.RunId)
 
 						dotPath := filepath.Join(dir, filenameBase+".dot")
 						// pngPath := filepath.Join(dir, filenameBase+".png")
@@ -184,6 +397,11 @@ func buildVirtualResultsList(results binding.UntypedList) fyne.CanvasObject {
 				}
 			}(res)
 
+			replayBtn := row.Objects[12].(*fyne.Container).Objects[0].(*widget.Button)
+			replayBtn.OnTapped = func() {
+				replay(res)
+			}
+
 			for _, obj := range row.Objects {
 				obj.Refresh()
 			}
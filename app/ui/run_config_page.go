@@ -1,6 +1,12 @@
 package ui
 
 import (
+	"fmt"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
@@ -10,6 +16,7 @@ import (
 	"fyne.io/fyne/v2/widget"
 
 	"graphmis/app/utils"
+	"graphmis/experiment"
 )
 
 func NewRunConfigPage(state *AppState) (fyne.CanvasObject, func()) {
@@ -20,6 +27,16 @@ func NewRunConfigPage(state *AppState) (fyne.CanvasObject, func()) {
 	runsEntry := widget.NewEntry()
 	runsEntry.SetPlaceHolder("Количество запусков")
 
+	seedEntry := widget.NewEntry()
+	seedEntry.SetPlaceHolder("Зерно запуска (пусто - случайное)")
+
+	workersEntry := widget.NewEntry()
+	workersEntry.SetPlaceHolder(fmt.Sprintf("Число потоков (доступно ядер: %d)", runtime.NumCPU()))
+
+	pinnedLabel := widget.NewLabel("Методы на выделенном потоке (точные замеры времени)")
+	pinnedLabel.TextStyle = fyne.TextStyle{Bold: true}
+	pinnedGroup := widget.NewCheckGroup(nil, nil)
+
 	fixGraphCheck := widget.NewCheck("", nil)
 
 	fixGraphLabel := widget.NewLabel("Фиксировать граф")
@@ -35,21 +52,49 @@ func NewRunConfigPage(state *AppState) (fyne.CanvasObject, func()) {
 			return
 		}
 
-		state.RunConfig = &RunConfig{
+		seed, err := utils.ParseSeed(seedEntry.Text)
+		if err != nil {
+			dialog.ShowError(err, fyne.CurrentApp().Driver().AllWindows()[0])
+			return
+		}
+		seedEntry.SetText(strconv.FormatInt(seed, 10))
+
+		workers := 1
+		if strings.TrimSpace(workersEntry.Text) != "" {
+			workers, err = utils.ParseUint(workersEntry.Text)
+			if err != nil {
+				dialog.ShowError(err, fyne.CurrentApp().Driver().AllWindows()[0])
+				return
+			}
+		}
+
+		state.RunConfig = &experiment.RunConfig{
 			IsGraphFixed: fixGraphCheck.Checked,
 			RunsNumber:   runs,
+			Seed:         seed,
+			Workers:      workers,
+			Pinned:       slices.Clone(pinnedGroup.Selected),
 		}
 		state.NavigationState.NextButton.Enable()
 	})
 
+	exportButton := widget.NewButtonWithIcon("Сохранить эксперимент", theme.DocumentSaveIcon(), func() {
+		showSaveSpecDialog(state)
+	})
+
 	form := container.NewVBox(
 		layout.NewSpacer(),
 		container.NewPadded(title),
 		layout.NewSpacer(),
 		container.NewPadded(runsEntry),
+		container.NewPadded(seedEntry),
+		container.NewPadded(workersEntry),
+		container.NewPadded(pinnedLabel),
+		container.NewPadded(pinnedGroup),
 		container.NewPadded(fixGraphContainer),
 		layout.NewSpacer(),
 		container.NewPadded(saveButton),
+		container.NewPadded(exportButton),
 		layout.NewSpacer(),
 	)
 
@@ -68,9 +113,30 @@ func NewRunConfigPage(state *AppState) (fyne.CanvasObject, func()) {
 			fixGraphCheck.SetChecked(true)
 			fixGraphCheck.Disable()
 		} else {
-			fixGraphCheck.SetChecked(false)
+			fixGraphCheck.SetChecked(state.RunConfig != nil && state.RunConfig.IsGraphFixed)
 			fixGraphCheck.Enable()
 		}
+
+		var methods []string
+		for _, solver := range state.Solvers {
+			methods = append(methods, solver.Name())
+		}
+		pinnedGroup.Options = methods
+		pinnedGroup.Selected = nil
+
+		if state.RunConfig != nil && state.RunConfig.RunsNumber > 0 {
+			runsEntry.SetText(strconv.Itoa(state.RunConfig.RunsNumber))
+			seedEntry.SetText(strconv.FormatInt(state.RunConfig.Seed, 10))
+			if state.RunConfig.Workers > 0 {
+				workersEntry.SetText(strconv.Itoa(state.RunConfig.Workers))
+			}
+			for _, name := range state.RunConfig.Pinned {
+				if slices.Contains(methods, name) {
+					pinnedGroup.Selected = append(pinnedGroup.Selected, name)
+				}
+			}
+		}
+		pinnedGroup.Refresh()
 	}
 	return centered, initFunc
 }
@@ -3,69 +3,23 @@ package ui
 import (
 	"fyne.io/fyne/v2/widget"
 
+	"graphmis/experiment"
 	"graphmis/graph"
 )
 
-type GeneratorConfig struct {
-	GraphType         graph.GraphType
-	MinVerticesNumber int
-	MaxVerticesNumber int
-	GraphDensity      float64
-}
-
-type MethodType string
-
-const (
-	MaghoutMethod      MethodType = "Метод Магу"
-	GreedySearchMethod MethodType = "Жадный поиск"
-)
-
-type MethodConfig interface {
-	MethodType() MethodType
-}
-
-type MaghoutConfig struct {
-	MethodConfig
-	ParallelDepth int
-}
-
-func (m *MaghoutConfig) MethodType() MethodType {
-	return MaghoutMethod
-}
-
-type GreedySearchConfig struct {
-	MethodConfig
-	Iterations int
-}
-
-func (g *GreedySearchConfig) MethodType() MethodType {
-	return GreedySearchMethod
-}
-
-type RunConfig struct {
-	IsGraphFixed bool
-	RunsNumber   int
-}
-
-type Result struct {
-	Graph    graph.Graph[string]
-	RunId    int
-	Method   string
-	Time     int64
-	Result   []string
-	F1Factor float64
-}
-
 type NavigationState struct {
 	NextButton *widget.Button
 	BackButton *widget.Button
 }
 
 type AppState struct {
-	GeneratorConfig *GeneratorConfig
+	GeneratorConfig *experiment.GeneratorConfig
 	Graph           graph.Graph[string]
-	MethodConfigs   []MethodConfig
-	RunConfig       *RunConfig
-	Results         []*Result
+	GraphPath       string
+	Sweep           *experiment.Sweep
+	Registry        *graph.Registry[string]
+	Solvers         []graph.Solver[string]
+	RunConfig       *experiment.RunConfig
+	Results         []*experiment.Result
 	NavigationState *NavigationState
 }
@@ -114,6 +114,29 @@ func RenderHistogram(title string, data plotter.Values, bins int, w io.Writer) e
 }
 
 func RenderGraphToFyneContainer[T comparable](g graph.Graph[T], size fyne.Size, verticesToColor ...[]T) fyne.CanvasObject {
+	colors := map[T]color.Color{}
+	if len(verticesToColor) > 0 && len(verticesToColor[0]) > 0 {
+		for _, v := range verticesToColor[0] {
+			colors[v] = color.NRGBA{R: 255, G: 0, B: 0, A: 255}
+		}
+	}
+	return renderGraph(g, size, colors)
+}
+
+func RenderHeatmapToFyneContainer[T comparable](g graph.Graph[T], size fyne.Size, frequency map[T]float64) fyne.CanvasObject {
+	colors := make(map[T]color.Color, len(frequency))
+	for v, f := range frequency {
+		colors[v] = HeatColor(f)
+	}
+	return renderGraph(g, size, colors)
+}
+
+func HeatColor(f float64) color.Color {
+	f = max(0, min(1, f))
+	return color.NRGBA{R: 255, G: uint8(255 * (1 - f)), B: uint8(255 * (1 - f)), A: 255}
+}
+
+func renderGraph[T comparable](g graph.Graph[T], size fyne.Size, colors map[T]color.Color) fyne.CanvasObject {
 	const (
 		circleR = 25
 	)
@@ -140,13 +163,6 @@ func RenderGraphToFyneContainer[T comparable](g graph.Graph[T], size fyne.Size,
 		posMap[v] = [2]float32{x, y}
 	}
 
-	colors := map[T]color.Color{}
-	if len(verticesToColor) > 0 && len(verticesToColor[0]) > 0 {
-		for _, v := range verticesToColor[0] {
-			colors[v] = color.NRGBA{R: 255, G: 0, B: 0, A: 255}
-		}
-	}
-
 	lineColor := color.NRGBA{R: 0, G: 0, B: 0, A: 255}
 	seenEdges := make(map[string]bool)
 
@@ -3,7 +3,11 @@ package utils
 import (
 	"fmt"
 	"image"
+	"math/rand"
 	"strconv"
+	"strings"
+
+	"graphmis/experiment"
 )
 
 func ParseUint(s string) (int, error) {
@@ -19,6 +23,32 @@ func ParseUint(s string) (int, error) {
 	return i, nil
 }
 
+func ParseNonNegativeInt(s string) (int, error) {
+	i, err := strconv.Atoi(s)
+	if err != nil {
+		return i, err
+	}
+
+	if i < 0 {
+		return i, fmt.Errorf("параметр должен быть неотрицательным целым числом")
+	}
+
+	return i, nil
+}
+
+func ParseSeed(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return rand.Int63(), nil
+	}
+
+	seed, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return seed, fmt.Errorf("зерно должно быть целым числом")
+	}
+	return seed, nil
+}
+
 func ParseFloatCoefficient(s string) (float64, error) {
 	f, err := strconv.ParseFloat(s, 64)
 	if err != nil {
@@ -32,6 +62,71 @@ func ParseFloatCoefficient(s string) (float64, error) {
 	return f, nil
 }
 
+func ParseIntValues(s string) ([]int, error) {
+	if from, to, step, ok := splitRange(s); ok {
+		a, err1 := strconv.Atoi(from)
+		b, err2 := strconv.Atoi(to)
+		c, err3 := strconv.Atoi(step)
+		if err := FindFirstError(err1, err2, err3); err != nil {
+			return nil, fmt.Errorf("ожидается диапазон вида 10..40:5")
+		}
+		return experiment.IntRange(a, b, c)
+	}
+
+	var values []int
+	for _, field := range strings.Split(s, ",") {
+		v, err := ParseUint(strings.TrimSpace(field))
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	return values, nil
+}
+
+func ParseFloatValues(s string) ([]float64, error) {
+	if from, to, step, ok := splitRange(s); ok {
+		a, err1 := strconv.ParseFloat(from, 64)
+		b, err2 := strconv.ParseFloat(to, 64)
+		c, err3 := strconv.ParseFloat(step, 64)
+		if err := FindFirstError(err1, err2, err3); err != nil {
+			return nil, fmt.Errorf("ожидается диапазон вида 0.1..0.5:0.2")
+		}
+		values, err := experiment.FloatRange(a, b, c)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range values {
+			if v < 0 || v > 1 {
+				return nil, fmt.Errorf("параметр должен быть в пределах [0; 1]")
+			}
+		}
+		return values, nil
+	}
+
+	var values []float64
+	for _, field := range strings.Split(s, ",") {
+		v, err := ParseFloatCoefficient(strings.TrimSpace(field))
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	return values, nil
+}
+
+func splitRange(s string) (from, to, step string, ok bool) {
+	from, rest, ok := strings.Cut(strings.TrimSpace(s), "..")
+	if !ok {
+		return "", "", "", false
+	}
+	to, step, ok = strings.Cut(rest, ":")
+	if !ok {
+		step = "1"
+	}
+	return strings.TrimSpace(from), strings.TrimSpace(to), strings.TrimSpace(step), true
+}
+
 func FindFirstError(errors ...error) error {
 	for _, err := range errors {
 		if err != nil {
@@ -0,0 +1,364 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"graphmis/experiment"
+	"graphmis/graph"
+)
+
+const usage = `Использование:
+  graphmis                         запуск графического интерфейса
+  graphmis run --config exp.yaml [--out results/] [флаги]
+  graphmis check --config exp.yaml [--save exp.toml]
+
+Описание эксперимента задаётся в формате YAML, TOML или JSON (по расширению файла).
+
+Флаги команды run:
+`
+
+func Run(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, usage)
+		return 2
+	}
+
+	switch args[0] {
+	case "run":
+		return runCommand(args[1:], os.Stderr)
+	case "check":
+		return checkCommand(args[1:], os.Stderr)
+	case "help", "-h", "--help":
+		runFlags(&runOptions{}, os.Stdout).Usage()
+		return 0
+	}
+
+	fmt.Fprintf(os.Stderr, "неизвестная команда %q\n\n%s", args[0], usage)
+	return 2
+}
+
+type runOptions struct {
+	config        string
+	out           string
+	runs          int
+	timeLimit     time.Duration
+	workers       int
+	saveSolutions bool
+}
+
+func runFlags(opts *runOptions, output io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet("run", flag.ContinueOnError)
+	fs.SetOutput(output)
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), usage)
+		fs.PrintDefaults()
+	}
+
+	fs.StringVar(&opts.config, "config", "", "файл описания эксперимента (.yaml, .toml или .json)")
+	fs.StringVar(&opts.out, "out", "results", "каталог для файлов результатов")
+	fs.IntVar(&opts.runs, "runs", 0, "число запусков (переопределяет значение из описания)")
+	fs.DurationVar(&opts.timeLimit, "time-limit", 0, "ограничение времени на метод, например 500ms или 2s (переопределяет значение из описания)")
+	fs.IntVar(&opts.workers, "workers", 0, "число параллельных потоков для независимых запусков (переопределяет значение из описания)")
+	fs.BoolVar(&opts.saveSolutions, "save-solutions", false, "сохранять найденные решения в формате DOT")
+	return fs
+}
+
+func runCommand(args []string, log io.Writer) int {
+	var opts runOptions
+	fs := runFlags(&opts, log)
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+	if opts.config == "" {
+		fmt.Fprintln(log, "не задан файл описания эксперимента (--config)")
+		fs.Usage()
+		return 2
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := runExperiments(ctx, opts, log); err != nil {
+		fmt.Fprintf(log, "ошибка: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+func checkCommand(args []string, log io.Writer) int {
+	fs := flag.NewFlagSet("check", flag.ContinueOnError)
+	fs.SetOutput(log)
+	config := fs.String("config", "", "файл описания эксперимента (.yaml, .toml или .json)")
+	save := fs.String("save", "", "сохранить проверенное описание в файл (формат по расширению)")
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+	if *config == "" {
+		fmt.Fprintln(log, "не задан файл описания эксперимента (--config)")
+		fs.PrintDefaults()
+		return 2
+	}
+
+	spec, err := experiment.LoadSpec(*config)
+	if err != nil {
+		fmt.Fprintf(log, "ошибка: %v\n", err)
+		return 1
+	}
+	if err := spec.Validate(graph.DefaultRegistry[string]()); err != nil {
+		fmt.Fprintf(log, "%s:\n%v\n", *config, err)
+		return 1
+	}
+
+	if *save != "" {
+		if err := experiment.SaveSpec(*save, spec); err != nil {
+			fmt.Fprintf(log, "ошибка: %v\n", err)
+			return 1
+		}
+		fmt.Fprintf(log, "Описание сохранено: %s\n", *save)
+	}
+
+	fmt.Fprintf(log, "%s: описание корректно\n", *config)
+	return 0
+}
+
+func runExperiments(ctx context.Context, opts runOptions, log io.Writer) error {
+	spec, err := experiment.LoadSpec(opts.config)
+	if err != nil {
+		return err
+	}
+	if opts.runs > 0 {
+		spec.Runs = opts.runs
+	}
+	if opts.timeLimit > 0 {
+		spec.ApplyTimeLimit(opts.timeLimit)
+	}
+	if opts.workers > 0 {
+		spec.Workers = opts.workers
+	}
+
+	experiments, err := spec.Experiments(graph.DefaultRegistry[string](), filepath.Dir(opts.config))
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(opts.out, 0755); err != nil {
+		return err
+	}
+
+	var results []*experiment.Result
+	var runErr error
+	for _, exp := range experiments {
+		fmt.Fprintf(log, "Эксперимент %q\n", exp.Name)
+		for ev := range experiment.Run(ctx, exp) {
+			switch ev.Kind {
+			case experiment.RunStarted:
+				if ev.Cell != nil {
+					fmt.Fprintf(log, "Итерация #%d, %v (зерно графа: %d)\n", ev.RunId, *ev.Cell, ev.GraphSeed)
+				} else {
+					fmt.Fprintf(log, "Итерация #%d (зерно графа: %d, вершин: %d)\n", ev.RunId, ev.GraphSeed, ev.Graph.Size())
+				}
+				fmt.Fprintf(log, "  Оценки α: %s\n", ev.Bounds)
+			case experiment.RunSkipped:
+				fmt.Fprintf(log, "Итерация #%d пропущена: %v\n", ev.RunId, ev.Err)
+			case experiment.MethodFinished:
+				res := ev.Result
+				results = append(results, res)
+				for _, name := range slices.Sorted(maps.Keys(ev.Stats.Counters)) {
+					fmt.Fprintf(log, "  [%s] %s = %d\n", res.Method, name, ev.Stats.Counters[name])
+				}
+				quality := ""
+				if res.Referenced {
+					quality = fmt.Sprintf(" | |S|/α: %.3f | Разрыв: %d | Перекрытие: %.2f", res.Quality.Ratio, res.Quality.Gap, res.Quality.Overlap)
+				}
+				fmt.Fprintf(log, "  [%s] Время: %d нс | F1: %s | Мощность: %d | Вес: %g%s | %s\n", res.Method, res.Time, res.F1Label(), len(res.Result), res.Weight, quality, res.StatusLabel())
+				if res.Optima != nil {
+					printOptima(log, res)
+				}
+				if !res.Check.Valid() {
+					fmt.Fprintf(log, "  [%s] ВНИМАНИЕ: некорректное решение: %s\n", res.Method, res.Check)
+				}
+			case experiment.Finished:
+				runErr = ev.Err
+			}
+		}
+		if runErr != nil {
+			break
+		}
+	}
+
+	timestamp := time.Now().Format("2006-01-02T15-04-05")
+	path := filepath.Join(opts.out, timestamp+"_results.csv")
+	if err := writeResults(path, results); err != nil {
+		return err
+	}
+	fmt.Fprintf(log, "Результаты сохранены: %s\n", path)
+
+	summaries := experiment.Summaries(results)
+	summaryPath := filepath.Join(opts.out, timestamp+"_summary.csv")
+	if err := writeSummary(summaryPath, summaries); err != nil {
+		return err
+	}
+	printSummaries(log, summaries)
+	fmt.Fprintf(log, "Сводка сохранена: %s\n", summaryPath)
+
+	comparisons := experiment.Compare(results)
+	testsPath := filepath.Join(opts.out, timestamp+"_tests.csv")
+	if err := writeComparisons(testsPath, comparisons); err != nil {
+		return err
+	}
+	printComparisons(log, comparisons)
+	fmt.Fprintf(log, "Результаты парных тестов сохранены: %s\n", testsPath)
+
+	if slices.ContainsFunc(results, func(res *experiment.Result) bool { return res.Optima != nil }) {
+		optimaPath := filepath.Join(opts.out, timestamp+"_optima.csv")
+		if err := writeFrequencies(optimaPath, results); err != nil {
+			return err
+		}
+		fmt.Fprintf(log, "Частоты вершин в оптимумах сохранены: %s\n", optimaPath)
+	}
+
+	if opts.saveSolutions {
+		if err := writeSolutions(opts.out, results); err != nil {
+			return err
+		}
+	}
+
+	if runErr != nil {
+		return fmt.Errorf("эксперимент прерван, сохранены частичные результаты: %w", runErr)
+	}
+	return nil
+}
+
+func writeResults(path string, results []*experiment.Result) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return experiment.WriteCSV(file, results)
+}
+
+func writeFrequencies(path string, results []*experiment.Result) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return experiment.WriteFrequencyCSV(file, results)
+}
+
+func printOptima(log io.Writer, res *experiment.Result) {
+	var forced, excluded int
+	var always []string
+	for _, v := range res.Graph.GetAllVertices() {
+		switch res.Optima.Frequency[v] {
+		case 1:
+			forced++
+			always = append(always, v)
+		case 0:
+			excluded++
+		}
+	}
+
+	complete := ""
+	if !res.Optima.Complete {
+		complete = " (перечень неполный)"
+	}
+	fmt.Fprintf(log, "  [%s] Вершин в оптимумах: во всех %d | ни в одном %d | свободных %d%s\n",
+		res.Method, forced, excluded, res.Graph.Size()-forced-excluded, complete)
+	if len(always) > 0 {
+		fmt.Fprintf(log, "  [%s] Вершины во всех оптимумах: %s\n", res.Method, strings.Join(always, ", "))
+	}
+}
+
+func writeSummary(path string, summaries []experiment.MethodSummary) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return experiment.WriteSummaryCSV(file, summaries)
+}
+
+func printSummaries(log io.Writer, summaries []experiment.MethodSummary) {
+	fmt.Fprintln(log, "Сводка:")
+	for _, s := range summaries {
+		prefix := s.Source
+		if s.Cell != nil {
+			prefix += ", " + s.Cell.String()
+		}
+		optimal := "нет эталона"
+		if s.Compared > 0 {
+			optimal = fmt.Sprintf("%.0f%%", s.OptimalShare*100)
+		}
+		fmt.Fprintf(log, "  [%s] %s: запусков %d | время %.0f нс [%.0f; %.0f] | мощность %.2f [%.2f; %.2f] | оптимальных %s | некорректных %d | прерванных %d\n",
+			prefix, s.Method, s.Runs,
+			s.Time.Mean, s.Time.CILow, s.Time.CIHigh,
+			s.Cardinality.Mean, s.Cardinality.CILow, s.Cardinality.CIHigh,
+			optimal, s.Invalid, s.Interrupted)
+	}
+}
+
+func writeComparisons(path string, comparisons []experiment.Comparison) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return experiment.WriteComparisonCSV(file, comparisons)
+}
+
+func printComparisons(log io.Writer, comparisons []experiment.Comparison) {
+	if len(comparisons) == 0 {
+		return
+	}
+
+	fmt.Fprintln(log, "Парные тесты (A - B):")
+	for _, c := range comparisons {
+		prefix := c.Source
+		if c.Cell != nil {
+			prefix += ", " + c.Cell.String()
+		}
+		fmt.Fprintf(log, "  [%s] %s: %s vs %s, пар %d, средняя разность %.3g", prefix, c.Metric, c.A, c.B, c.Pairs, c.MeanDiff)
+		for _, test := range c.Tests {
+			fmt.Fprintf(log, " | %s p=%.4f %s=%.3f", test.Name, test.PValue, test.EffectName, test.EffectSize)
+		}
+		fmt.Fprintln(log)
+	}
+}
+
+func writeSolutions(dir string, results []*experiment.Result) error {
+	for _, res := range results {
+		name := fmt.Sprintf("%s_%s_run%d.dot", fileName(res.Source), fileName(res.Method), res.RunId)
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(res.Graph.Dot(res.Result)), 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func fileName(name string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), " ", "_")
+}
@@ -0,0 +1,289 @@
+package experiment
+
+import (
+	"cmp"
+	"encoding/csv"
+	"io"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+)
+
+type Metric struct {
+	Name  string
+	Value func(res *Result) float64
+}
+
+var (
+	TimeMetric        = Metric{Name: "Время (нс)", Value: func(res *Result) float64 { return float64(res.Time) }}
+	CardinalityMetric = Metric{Name: "Мощность", Value: func(res *Result) float64 { return float64(len(res.Result)) }}
+)
+
+type PairedTest struct {
+	Name       string
+	Statistic  float64
+	PValue     float64
+	EffectName string
+	EffectSize float64
+}
+
+type Comparison struct {
+	Source   string
+	Cell     *Cell
+	Metric   string
+	A        string
+	B        string
+	Pairs    int
+	MeanDiff float64
+	Tests    []PairedTest
+}
+
+func Compare(results []*Result, metrics ...Metric) []Comparison {
+	if len(metrics) == 0 {
+		metrics = []Metric{CardinalityMetric, TimeMetric}
+	}
+
+	type groupKey struct {
+		source string
+		cell   Cell
+	}
+
+	type group struct {
+		cell    *Cell
+		methods []string
+		runs    map[int]map[string]*Result
+	}
+
+	var order []groupKey
+	groups := make(map[groupKey]*group)
+	for _, res := range results {
+		key := groupKey{source: res.Source}
+		if res.Cell != nil {
+			key.cell = *res.Cell
+		}
+
+		g, ok := groups[key]
+		if !ok {
+			g = &group{cell: res.Cell, runs: make(map[int]map[string]*Result)}
+			groups[key] = g
+			order = append(order, key)
+		}
+		if !slices.Contains(g.methods, res.Method) {
+			g.methods = append(g.methods, res.Method)
+		}
+		if g.runs[res.RunId] == nil {
+			g.runs[res.RunId] = make(map[string]*Result)
+		}
+		g.runs[res.RunId][res.Method] = res
+	}
+
+	var comparisons []Comparison
+	for _, key := range order {
+		g := groups[key]
+		runIds := slices.Sorted(maps.Keys(g.runs))
+
+		for _, metric := range metrics {
+			for i, a := range g.methods {
+				for _, b := range g.methods[i+1:] {
+					var diffs []float64
+					for _, id := range runIds {
+						ra, okA := g.runs[id][a]
+						rb, okB := g.runs[id][b]
+						if okA && okB {
+							diffs = append(diffs, metric.Value(ra)-metric.Value(rb))
+						}
+					}
+					if len(diffs) == 0 {
+						continue
+					}
+
+					comparisons = append(comparisons, Comparison{
+						Source:   key.source,
+						Cell:     g.cell,
+						Metric:   metric.Name,
+						A:        a,
+						B:        b,
+						Pairs:    len(diffs),
+						MeanDiff: mean(diffs),
+						Tests:    []PairedTest{WilcoxonTest(diffs), SignTest(diffs), PairedTTest(diffs)},
+					})
+				}
+			}
+		}
+	}
+	return comparisons
+}
+
+func WilcoxonTest(diffs []float64) PairedTest {
+	test := PairedTest{Name: "Уилкоксон", PValue: 1, EffectName: "r"}
+
+	var nonZero []float64
+	for _, d := range diffs {
+		if d != 0 {
+			nonZero = append(nonZero, d)
+		}
+	}
+	n := len(nonZero)
+	if n == 0 {
+		return test
+	}
+
+	slices.SortFunc(nonZero, func(a, b float64) int { return cmp.Compare(math.Abs(a), math.Abs(b)) })
+
+	doubled := make([]int, n)
+	tieCorrection := 0.0
+	for i := 0; i < n; {
+		j := i
+		for j < n && math.Abs(nonZero[j]) == math.Abs(nonZero[i]) {
+			j++
+		}
+		for k := i; k < j; k++ {
+			doubled[k] = i + j + 1
+		}
+		t := float64(j - i)
+		tieCorrection += t*t*t - t
+		i = j
+	}
+
+	wPlus2 := 0
+	total2 := 0
+	for i, d := range nonZero {
+		total2 += doubled[i]
+		if d > 0 {
+			wPlus2 += doubled[i]
+		}
+	}
+	wPlus := float64(wPlus2) / 2
+	wMinus := float64(total2-wPlus2) / 2
+
+	test.Statistic = min(wPlus, wMinus)
+	test.EffectSize = (wPlus - wMinus) / (wPlus + wMinus)
+
+	if n <= 50 {
+		counts := make([]float64, total2+1)
+		counts[0] = 1
+		for _, r := range doubled {
+			for s := total2; s >= r; s-- {
+				counts[s] += counts[s-r]
+			}
+		}
+		low := min(wPlus2, total2-wPlus2)
+		var tail, all float64
+		for s, c := range counts {
+			all += c
+			if s <= low {
+				tail += c
+			}
+		}
+		test.PValue = min(1, 2*tail/all)
+		return test
+	}
+
+	nf := float64(n)
+	expected := nf * (nf + 1) / 4
+	variance := nf*(nf+1)*(2*nf+1)/24 - tieCorrection/48
+	z := (math.Abs(wPlus-expected) - 0.5) / math.Sqrt(variance)
+	test.PValue = min(1, 2*(1-normalCDF(max(z, 0))))
+	return test
+}
+
+func SignTest(diffs []float64) PairedTest {
+	test := PairedTest{Name: "Знаков", PValue: 1, EffectName: "доля"}
+
+	positive, negative := 0, 0
+	for _, d := range diffs {
+		switch {
+		case d > 0:
+			positive++
+		case d < 0:
+			negative++
+		}
+	}
+	n := positive + negative
+	if n == 0 {
+		return test
+	}
+
+	k := min(positive, negative)
+	var tail float64
+	for i := 0; i <= k; i++ {
+		tail += math.Exp(logBinomial(n, i) - float64(n)*math.Ln2)
+	}
+
+	test.Statistic = float64(positive)
+	test.PValue = min(1, 2*tail)
+	test.EffectSize = float64(positive-negative) / float64(n)
+	return test
+}
+
+func PairedTTest(diffs []float64) PairedTest {
+	test := PairedTest{Name: "t-тест", PValue: 1, EffectName: "d"}
+
+	s := Summarize(diffs)
+	if s.Count < 2 {
+		return test
+	}
+	if s.StdDev == 0 {
+		if s.Mean != 0 {
+			test.PValue = 0
+			test.Statistic = math.Copysign(math.Inf(1), s.Mean)
+			test.EffectSize = math.Copysign(math.Inf(1), s.Mean)
+		}
+		return test
+	}
+
+	t := s.Mean / (s.StdDev / math.Sqrt(float64(s.Count)))
+	test.Statistic = t
+	test.PValue = min(1, 2*studentTCDF(-math.Abs(t), float64(s.Count-1)))
+	test.EffectSize = s.Mean / s.StdDev
+	return test
+}
+
+func WriteComparisonCSV(w io.Writer, comparisons []Comparison) error {
+	writer := csv.NewWriter(w)
+
+	header := []string{"Источник", "n", "p", "Метрика", "Метод A", "Метод B", "Пар", "Средняя разность A-B"}
+	if len(comparisons) > 0 {
+		for _, test := range comparisons[0].Tests {
+			header = append(header, test.Name+": статистика", test.Name+": p-значение", test.Name+": эффект ("+test.EffectName+")")
+		}
+	}
+	writer.Write(header)
+
+	for _, c := range comparisons {
+		var n, p string
+		if c.Cell != nil {
+			n = strconv.Itoa(c.Cell.Vertices)
+			p = strconv.FormatFloat(c.Cell.Density, 'f', -1, 64)
+		}
+
+		row := []string{c.Source, n, p, c.Metric, c.A, c.B, strconv.Itoa(c.Pairs), formatStat(c.MeanDiff)}
+		for _, test := range c.Tests {
+			row = append(row, formatStat(test.Statistic), formatStat(test.PValue), formatStat(test.EffectSize))
+		}
+		writer.Write(row)
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func mean(values []float64) float64 {
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+func logBinomial(n, k int) float64 {
+	a, _ := math.Lgamma(float64(n + 1))
+	b, _ := math.Lgamma(float64(k + 1))
+	c, _ := math.Lgamma(float64(n - k + 1))
+	return a - b - c
+}
+
+func normalCDF(z float64) float64 {
+	return 0.5 * math.Erfc(-z/math.Sqrt2)
+}
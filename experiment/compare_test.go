@@ -0,0 +1,98 @@
+package experiment
+
+import (
+	"testing"
+)
+
+func sequence(from, to int, sign float64) []float64 {
+	var values []float64
+	for v := from; v <= to; v++ {
+		values = append(values, sign*float64(v))
+	}
+	return values
+}
+
+func TestWilcoxonTest(t *testing.T) {
+	tests := []struct {
+		name      string
+		diffs     []float64
+		statistic float64
+		pValue    float64
+		effect    float64
+	}{
+		{"все разности положительны", []float64{1, 2, 3, 4, 5}, 0, 0.0625, 1},
+		{"все разности отрицательны", []float64{-1, -2, -3, -4, -5}, 0, 0.0625, -1},
+		{"связанные ранги", []float64{2, 2, 3, -1, 4}, 1, 0.125, 0.866667},
+		{"связанные ранги, полуцелая сумма", []float64{1, 2, 3, 3, -2, 4}, 2.5, 0.125, 0.761905},
+		{"нулевые разности отбрасываются", []float64{0, 1, 2, 0, 3, 4, 5}, 0, 0.0625, 1},
+		{"нормальное приближение", append(sequence(1, 10, -1), sequence(11, 60, 1)...), 55, 2.4949e-10, 0.939891},
+		{"нормальное приближение, смешанные знаки", append(sequence(1, 20, -1), sequence(21, 60, 1)...), 210, 2.1457e-7, 0.770492},
+		{"только нули", []float64{0, 0, 0}, 0, 1, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test := WilcoxonTest(tt.diffs)
+			assertClose(t, "статистика", test.Statistic, tt.statistic, 1e-9)
+			assertClose(t, "p-значение", test.PValue, tt.pValue, max(tt.pValue*1e-3, 1e-12))
+			assertClose(t, "эффект", test.EffectSize, tt.effect, 1e-5)
+		})
+	}
+}
+
+func TestSignTest(t *testing.T) {
+	tests := []struct {
+		name      string
+		diffs     []float64
+		statistic float64
+		pValue    float64
+		effect    float64
+	}{
+		{"восемь из девяти", []float64{1, 2, 3, -1, 5, 6, 7, 8, 0, 2}, 8, 0.0390625, 0.777778},
+		{"поровну", []float64{1, -1}, 1, 1, 0},
+		{"все положительны", []float64{1, 2, 3, 4, 5}, 5, 0.0625, 1},
+		{"только нули", []float64{0, 0}, 0, 1, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test := SignTest(tt.diffs)
+			assertClose(t, "статистика", test.Statistic, tt.statistic, 1e-9)
+			assertClose(t, "p-значение", test.PValue, tt.pValue, 1e-9)
+			assertClose(t, "эффект", test.EffectSize, tt.effect, 1e-5)
+		})
+	}
+}
+
+func TestPairedTTest(t *testing.T) {
+	tests := []struct {
+		name      string
+		diffs     []float64
+		statistic float64
+		pValue    float64
+		effect    float64
+	}{
+		{"десять пар", []float64{1, 2, 0, 3, 1, 2, -1, 2, 1, 4}, 3.308466, 0.009106, 1.046229},
+		{"восемь пар", []float64{2, 3, 1, 4, 2, 3, -1, 2}, 3.741657, 0.007247, 1.322876},
+		{"десять пар, дробные разности", []float64{1.2, 0.8, 1.5, 0.9, 1.1, 1.4, 0.7, 1.3, -0.2, 1.0}, 6.318626, 0.0001379, 1.998125},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test := PairedTTest(tt.diffs)
+			assertClose(t, "статистика", test.Statistic, tt.statistic, 1e-5)
+			assertClose(t, "p-значение", test.PValue, tt.pValue, max(tt.pValue*1e-3, 1e-6))
+			assertClose(t, "эффект", test.EffectSize, tt.effect, 1e-5)
+		})
+	}
+
+	if test := PairedTTest([]float64{2}); test.PValue != 1 {
+		t.Errorf("одна пара: p = %g, ожидалось 1", test.PValue)
+	}
+	if test := PairedTTest([]float64{0, 0, 0}); test.PValue != 1 {
+		t.Errorf("нулевые разности: p = %g, ожидалось 1", test.PValue)
+	}
+	if test := PairedTTest([]float64{1, 1, 1}); test.PValue != 0 {
+		t.Errorf("постоянные разности: p = %g, ожидалось 0", test.PValue)
+	}
+}
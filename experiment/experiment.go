@@ -0,0 +1,85 @@
+package experiment
+
+import (
+	"fmt"
+	"math"
+
+	"graphmis/graph"
+)
+
+type GeneratorConfig struct {
+	GraphType         graph.GraphType
+	MinVerticesNumber int
+	MaxVerticesNumber int
+	GraphDensity      float64
+	Seed              int64
+}
+
+func (c GeneratorConfig) Generate(seed int64) (graph.Graph[string], error) {
+	return GenerateGraph(c.GraphType, c.MinVerticesNumber, c.MaxVerticesNumber, c.GraphDensity, seed)
+}
+
+func (c GeneratorConfig) Validate() error {
+	return validateGenerator(c.MinVerticesNumber, c.MaxVerticesNumber, c.GraphDensity)
+}
+
+type RunConfig struct {
+	IsGraphFixed bool
+	RunsNumber   int
+	Seed         int64
+	Workers      int
+	Pinned       []string
+}
+
+type Experiment struct {
+	Name      string
+	Path      string
+	Graph     graph.Graph[string]
+	Generator *GeneratorConfig
+	Sweep     *Sweep
+	Run       RunConfig
+	Solvers   []graph.Solver[string]
+}
+
+type Result struct {
+	Source      string
+	Graph       graph.Graph[string]
+	RunId       int
+	Cell        *Cell
+	Method      string
+	Config      graph.Params
+	GraphSeed   int64
+	Seed        int64
+	Exact       bool
+	Optimal     bool
+	Interrupted bool
+	Status      graph.RunStatus
+	Time        int64
+	Result      []string
+	Weight      float64
+	F1Factor    float64
+	Quality     graph.Quality
+	Referenced  bool
+	Check       graph.Verification[string]
+	Bounds      graph.AlphaBounds
+	BoundGap    int
+	Optima      *graph.MaximumSets[string]
+	Trace       []graph.TracePoint
+}
+
+func (r *Result) F1Label() string {
+	if math.IsNaN(r.F1Factor) {
+		return "—"
+	}
+	return fmt.Sprintf("%.2f", r.F1Factor)
+}
+
+func (r *Result) StatusLabel() string {
+	if !r.Check.Valid() {
+		return "некорректно"
+	}
+	if r.Optimal {
+		return "оптимально"
+	}
+	return r.Status.String()
+}
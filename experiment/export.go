@@ -0,0 +1,105 @@
+package experiment
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+)
+
+func WriteCSV(w io.Writer, results []*Result) error {
+	writer := csv.NewWriter(w)
+
+	writer.Write([]string{"Источник", "ID", "n", "p", "Метод", "Время (нс)", "F1-score", "Мощность", "Вес", "α", "|S|/α", "Разрыв", "Оптимум", "Перекрытие", "Нижняя оценка α", "Верхняя оценка α", "Разрыв до верхней оценки", "Статус", "Проверка", "Зерно графа", "Зерно метода"})
+	for _, res := range results {
+		var n, p string
+		if res.Cell != nil {
+			n = strconv.Itoa(res.Cell.Vertices)
+			p = strconv.FormatFloat(res.Cell.Density, 'f', -1, 64)
+		}
+
+		var f1 string
+		if !math.IsNaN(res.F1Factor) {
+			f1 = fmt.Sprintf("%.2f", res.F1Factor)
+		}
+
+		var alpha, ratio, gap, optimal, overlap string
+		if res.Referenced {
+			alpha = strconv.Itoa(res.Quality.Alpha)
+			ratio = fmt.Sprintf("%.4f", res.Quality.Ratio)
+			gap = strconv.Itoa(res.Quality.Gap)
+			optimal = "нет"
+			if res.Quality.Optimal {
+				optimal = "да"
+			}
+			overlap = fmt.Sprintf("%.4f", res.Quality.Overlap)
+		}
+
+		writer.Write([]string{
+			res.Source,
+			strconv.Itoa(res.RunId),
+			n,
+			p,
+			res.Method,
+			strconv.FormatInt(res.Time, 10),
+			f1,
+			strconv.Itoa(len(res.Result)),
+			strconv.FormatFloat(res.Weight, 'f', -1, 64),
+			alpha,
+			ratio,
+			gap,
+			optimal,
+			overlap,
+			strconv.Itoa(res.Bounds.BestLower().Value),
+			strconv.Itoa(res.Bounds.BestUpper().Value),
+			strconv.Itoa(res.BoundGap),
+			res.StatusLabel(),
+			res.Check.String(),
+			strconv.FormatInt(res.GraphSeed, 10),
+			strconv.FormatInt(res.Seed, 10),
+		})
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func WriteFrequencyCSV(w io.Writer, results []*Result) error {
+	writer := csv.NewWriter(w)
+
+	writer.Write([]string{"Источник", "ID", "n", "p", "Метод", "α", "Оптимальных решений", "Полный перечень", "Вершина", "Доля оптимумов"})
+	for _, res := range results {
+		if res.Optima == nil {
+			continue
+		}
+
+		var n, p string
+		if res.Cell != nil {
+			n = strconv.Itoa(res.Cell.Vertices)
+			p = strconv.FormatFloat(res.Cell.Density, 'f', -1, 64)
+		}
+		complete := "нет"
+		if res.Optima.Complete {
+			complete = "да"
+		}
+
+		for _, v := range res.Graph.GetAllVertices() {
+			writer.Write([]string{
+				res.Source,
+				strconv.Itoa(res.RunId),
+				n,
+				p,
+				res.Method,
+				strconv.Itoa(res.Optima.Alpha),
+				strconv.Itoa(len(res.Optima.Sets)),
+				complete,
+				v,
+				fmt.Sprintf("%.4f", res.Optima.Frequency[v]),
+			})
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
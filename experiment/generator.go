@@ -0,0 +1,56 @@
+package experiment
+
+import (
+	"fmt"
+	"math/rand"
+
+	"graphmis/graph"
+)
+
+func GenerateGraph(gt graph.GraphType, minVertices, maxVertices int, edgeProb float64, seed int64) (graph.Graph[string], error) {
+	if err := validateGenerator(minVertices, maxVertices, edgeProb); err != nil {
+		return nil, err
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+
+	var n int
+	if minVertices == maxVertices {
+		n = minVertices
+	} else {
+		n = minVertices + rng.Intn(maxVertices-minVertices+1)
+	}
+
+	g := graph.NewGraph[string](gt)
+
+	for i := range n {
+		for j := range n {
+			if i == j {
+				continue
+			}
+			if rng.Float64() < edgeProb {
+				from := fmt.Sprint(i + 1)
+				to := fmt.Sprint(j + 1)
+				g.AddEdge(&from, &to)
+			} else {
+				v := fmt.Sprint(i + 1)
+				g.AddVertex(&v)
+			}
+		}
+	}
+
+	return g, nil
+}
+
+func validateGenerator(minVertices, maxVertices int, edgeProb float64) error {
+	if minVertices < 0 {
+		return fmt.Errorf("минимум вершин не может быть отрицательным")
+	}
+	if maxVertices < minVertices {
+		return fmt.Errorf("максимум вершин должен быть не меньше минимума")
+	}
+	if edgeProb < 0 || edgeProb > 1 {
+		return fmt.Errorf("плотность графа должна быть в пределах [0; 1]")
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package experiment
+
+import (
+	"runtime"
+	"sync"
+)
+
+type pinnedWorker struct {
+	tasks chan func()
+	mu    sync.RWMutex
+}
+
+func newPinnedWorker() *pinnedWorker {
+	w := &pinnedWorker{tasks: make(chan func())}
+	go func() {
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+
+		for task := range w.tasks {
+			task()
+		}
+	}()
+	return w
+}
+
+func (w *pinnedWorker) do(task func()) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	done := make(chan struct{})
+	w.tasks <- func() {
+		task()
+		close(done)
+	}
+	<-done
+}
+
+func (w *pinnedWorker) share(task func()) {
+	if w == nil {
+		task()
+		return
+	}
+
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	task()
+}
+
+func (w *pinnedWorker) stop() {
+	close(w.tasks)
+}
@@ -0,0 +1,304 @@
+package experiment
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"slices"
+
+	"graphmis/graph"
+)
+
+var ErrNoGenerator = errors.New("невозможно сгенерировать граф: конфигурация отсутствует")
+
+type EventKind int
+
+const (
+	RunStarted EventKind = iota
+	RunSkipped
+	MethodFinished
+	Finished
+)
+
+type Event struct {
+	Kind      EventKind
+	RunId     int
+	Cell      *Cell
+	GraphSeed int64
+	Graph     graph.Graph[string]
+	Bounds    graph.AlphaBounds
+	Result    *Result
+	Stats     graph.Stats
+	Progress  float64
+	Err       error
+}
+
+func Run(ctx context.Context, exp Experiment) <-chan Event {
+	events := make(chan Event, 64)
+
+	go func() {
+		defer close(events)
+
+		err := run(ctx, exp, func(ev Event) {
+			events <- ev
+		})
+		events <- Event{Kind: Finished, Progress: 1, Err: err}
+	}()
+
+	return events
+}
+
+func Collect(ctx context.Context, exp Experiment) ([]*Result, error) {
+	var results []*Result
+	err := run(ctx, exp, func(ev Event) {
+		if ev.Kind == MethodFinished {
+			results = append(results, ev.Result)
+		}
+	})
+	return results, err
+}
+
+type job struct {
+	runId       int
+	cell        *Cell
+	generator   *GeneratorConfig
+	graph       graph.Graph[string]
+	graphSeed   int64
+	solverSeeds []int64
+	err         error
+	events      chan Event
+}
+
+func run(ctx context.Context, exp Experiment, emit func(Event)) error {
+	if err := validate(exp); err != nil {
+		return err
+	}
+
+	solvers := slices.Clone(exp.Solvers)
+	slices.SortStableFunc(solvers, func(a, b graph.Solver[string]) int {
+		if a.Exact() == b.Exact() {
+			return 0
+		}
+		if a.Exact() {
+			return -1
+		}
+		return 1
+	})
+
+	jobs := planJobs(exp, len(solvers))
+	totalSteps := max(float64(len(jobs)*len(solvers)), 1)
+
+	var pinned *pinnedWorker
+	if len(exp.Run.Pinned) > 0 {
+		pinned = newPinnedWorker()
+		defer pinned.stop()
+	}
+
+	queue := make(chan *job)
+	go func() {
+		defer close(queue)
+		for _, j := range jobs {
+			queue <- j
+		}
+	}()
+
+	for range max(exp.Run.Workers, 1) {
+		go func() {
+			for j := range queue {
+				runJob(ctx, j, exp, solvers, pinned)
+			}
+		}()
+	}
+
+	var currentStep float64
+	for _, j := range jobs {
+		for ev := range j.events {
+			switch ev.Kind {
+			case RunSkipped:
+				currentStep += float64(len(solvers))
+			case MethodFinished:
+				currentStep++
+			}
+			ev.Progress = currentStep / totalSteps
+			emit(ev)
+		}
+	}
+
+	return ctx.Err()
+}
+
+func validate(exp Experiment) error {
+	if exp.Sweep != nil {
+		return exp.Sweep.Validate()
+	}
+	if exp.Generator != nil {
+		return exp.Generator.Validate()
+	}
+	return nil
+}
+
+func planJobs(exp Experiment, solversNumber int) []*job {
+	seeds := rand.New(rand.NewSource(exp.Run.Seed))
+
+	type plan struct {
+		cell      *Cell
+		generator *GeneratorConfig
+		graph     graph.Graph[string]
+	}
+
+	plans := []plan{{generator: exp.Generator, graph: exp.Graph}}
+	if exp.Sweep != nil {
+		var base GeneratorConfig
+		if exp.Generator != nil {
+			base = *exp.Generator
+		}
+
+		plans = nil
+		for _, cell := range exp.Sweep.Grid() {
+			generator := base
+			generator.MinVerticesNumber = cell.Vertices
+			generator.MaxVerticesNumber = cell.Vertices
+			generator.GraphDensity = cell.Density
+			generator.Seed = seeds.Int63()
+			plans = append(plans, plan{cell: &cell, generator: &generator})
+		}
+	}
+
+	var jobs []*job
+	for _, p := range plans {
+		for i := range exp.Run.RunsNumber {
+			j := &job{
+				runId:     len(jobs) + 1,
+				cell:      p.cell,
+				generator: p.generator,
+				graph:     p.graph,
+				events:    make(chan Event, solversNumber+1),
+			}
+			jobs = append(jobs, j)
+
+			if p.generator != nil {
+				j.graphSeed = p.generator.Seed
+			}
+			nextGraphSeed := seeds.Int63()
+
+			if p.graph == nil || (!exp.Run.IsGraphFixed && i > 0) {
+				if p.generator == nil {
+					j.err = ErrNoGenerator
+					continue
+				}
+				if i > 0 && !exp.Run.IsGraphFixed {
+					j.graphSeed = nextGraphSeed
+					j.graph = nil
+				} else {
+					p.graph, j.err = p.generator.Generate(j.graphSeed)
+					j.graph = p.graph
+				}
+			}
+
+			j.solverSeeds = make([]int64, solversNumber)
+			for k := range j.solverSeeds {
+				j.solverSeeds[k] = seeds.Int63()
+			}
+		}
+	}
+	return jobs
+}
+
+func runJob(ctx context.Context, j *job, exp Experiment, solvers []graph.Solver[string], pinned *pinnedWorker) {
+	defer close(j.events)
+
+	if ctx.Err() != nil {
+		return
+	}
+	g := j.graph
+	var bounds graph.AlphaBounds
+	pinned.share(func() {
+		if g == nil && j.err == nil {
+			g, j.err = j.generator.Generate(j.graphSeed)
+		}
+		if j.err == nil {
+			bounds = graph.ComputeAlphaBounds(g)
+		}
+	})
+	if j.err != nil {
+		j.events <- Event{Kind: RunSkipped, RunId: j.runId, Cell: j.cell, GraphSeed: j.graphSeed, Err: j.err}
+		return
+	}
+
+	j.events <- Event{Kind: RunStarted, RunId: j.runId, Cell: j.cell, GraphSeed: j.graphSeed, Graph: g, Bounds: bounds}
+
+	var exactSolution []string
+	var optima [][]string
+
+	for k, solver := range solvers {
+		if ctx.Err() != nil {
+			return
+		}
+
+		var solution graph.Solution[string]
+		var stats graph.Stats
+		solve := func() {
+			runCtx, stop := graph.WithLimits(ctx, solver.Limits())
+			solution, stats = solver.Solve(runCtx, g, graph.WithSeed(j.solverSeeds[k]))
+			stop()
+		}
+		var check graph.Verification[string]
+		if pinned != nil && slices.Contains(exp.Run.Pinned, solver.Name()) {
+			pinned.do(solve)
+		} else {
+			pinned.share(solve)
+		}
+		pinned.share(func() {
+			check = graph.VerifyIndependentSet(g, solution.Vertices)
+		})
+
+		if solver.Exact() && stats.Optimal && check.Valid() {
+			exactSolution = solution.Vertices
+			optima = [][]string{solution.Vertices}
+			if solution.Optima != nil && len(solution.Optima.Sets) > 0 {
+				optima = solution.Optima.Sets
+			}
+		}
+		f1 := math.NaN()
+		if exactSolution != nil {
+			f1 = graph.ComputeF1Factor(exactSolution, solution.Vertices)
+		}
+		quality, referenced := graph.ComputeQuality(solution.Vertices, optima)
+		quality.Optimal = quality.Optimal && check.Independent()
+
+		j.events <- Event{
+			Kind:      MethodFinished,
+			RunId:     j.runId,
+			Cell:      j.cell,
+			GraphSeed: j.graphSeed,
+			Graph:     g,
+			Result: &Result{
+				Source:      exp.Name,
+				Graph:       g,
+				RunId:       j.runId,
+				Cell:        j.cell,
+				Method:      solver.Name(),
+				Config:      solver.Config(),
+				GraphSeed:   j.graphSeed,
+				Seed:        stats.Seed,
+				Exact:       solver.Exact(),
+				Optimal:     stats.Optimal,
+				Interrupted: stats.Interrupted,
+				Status:      stats.Status,
+				Time:        stats.Elapsed.Nanoseconds(),
+				Result:      solution.Vertices,
+				Weight:      solution.Weight,
+				F1Factor:    f1,
+				Quality:     quality,
+				Referenced:  referenced,
+				Check:       check,
+				Bounds:      bounds,
+				BoundGap:    bounds.BestUpper().Value - len(solution.Vertices),
+				Optima:      solution.Optima,
+				Trace:       stats.Trace,
+			},
+			Stats: stats,
+		}
+	}
+}
@@ -0,0 +1,238 @@
+package experiment
+
+import (
+	"context"
+	"math"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"graphmis/graph"
+)
+
+func newSolvers(t *testing.T, names ...string) []graph.Solver[string] {
+	t.Helper()
+
+	registry := graph.DefaultRegistry[string]()
+	solvers := make([]graph.Solver[string], len(names))
+	for i, name := range names {
+		solver, err := registry.New(name, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		solvers[i] = solver
+	}
+	return solvers
+}
+
+func TestCollectRejectsInvalidGenerator(t *testing.T) {
+	tests := []struct {
+		name      string
+		generator GeneratorConfig
+	}{
+		{"минимум больше максимума", GeneratorConfig{MinVerticesNumber: 5, MaxVerticesNumber: 3, GraphDensity: 0.5}},
+		{"отрицательный минимум", GeneratorConfig{MinVerticesNumber: -1, MaxVerticesNumber: 3, GraphDensity: 0.5}},
+		{"плотность больше единицы", GeneratorConfig{MinVerticesNumber: 3, MaxVerticesNumber: 5, GraphDensity: 1.5}},
+		{"отрицательная плотность", GeneratorConfig{MinVerticesNumber: 3, MaxVerticesNumber: 5, GraphDensity: -0.1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exp := Experiment{
+				Generator: &tt.generator,
+				Run:       RunConfig{RunsNumber: 3, Workers: 2},
+				Solvers:   newSolvers(t, graph.BranchAndBoundSolver),
+			}
+
+			results, err := Collect(context.Background(), exp)
+			if err == nil {
+				t.Fatal("ожидалась ошибка конфигурации генератора")
+			}
+			if len(results) != 0 {
+				t.Fatalf("получено %d результатов, ожидалось 0", len(results))
+			}
+
+			var finished *Event
+			for ev := range Run(context.Background(), exp) {
+				if ev.Kind == Finished {
+					finished = &ev
+				}
+			}
+			if finished == nil || finished.Err == nil {
+				t.Fatal("событие завершения не содержит ошибки")
+			}
+		})
+	}
+}
+
+func TestRunSkipsJobWithoutGraph(t *testing.T) {
+	exp := Experiment{
+		Run:     RunConfig{RunsNumber: 2},
+		Solvers: newSolvers(t, graph.BranchAndBoundSolver),
+	}
+
+	skipped := 0
+	for ev := range Run(context.Background(), exp) {
+		switch ev.Kind {
+		case RunSkipped:
+			skipped++
+			if ev.Err != ErrNoGenerator {
+				t.Fatalf("ошибка %v, ожидалась %v", ev.Err, ErrNoGenerator)
+			}
+		case MethodFinished:
+			t.Fatal("метод не должен запускаться без графа")
+		}
+	}
+	if skipped != 2 {
+		t.Fatalf("пропущено %d запусков, ожидалось 2", skipped)
+	}
+}
+
+type probeSolver struct {
+	name    string
+	pinned  bool
+	running *atomic.Int32
+	pinning *atomic.Int32
+	overlap *atomic.Bool
+}
+
+func (p *probeSolver) Name() string              { return p.name }
+func (p *probeSolver) Exact() bool               { return false }
+func (p *probeSolver) Params() []graph.ParamSpec { return nil }
+func (p *probeSolver) Config() graph.Params      { return nil }
+func (p *probeSolver) Limits() graph.Limits      { return graph.Limits{} }
+
+func (p *probeSolver) Solve(ctx context.Context, g graph.Graph[string], opts ...graph.MISOption) (graph.Solution[string], graph.Stats) {
+	p.running.Add(1)
+	defer p.running.Add(-1)
+	if p.pinned {
+		p.pinning.Add(1)
+		defer p.pinning.Add(-1)
+	}
+
+	for range 5 {
+		if p.pinned && p.running.Load() != 1 || !p.pinned && p.pinning.Load() != 0 {
+			p.overlap.Store(true)
+		}
+		time.Sleep(100 * time.Microsecond)
+	}
+	return graph.Solution[string]{Vertices: graph.MISGreedy(ctx, g)}, graph.Stats{}
+}
+
+func TestPinnedSolveIsExclusive(t *testing.T) {
+	var running, pinning atomic.Int32
+	var overlap atomic.Bool
+	probe := func(name string, pinned bool) graph.Solver[string] {
+		return &probeSolver{name: name, pinned: pinned, running: &running, pinning: &pinning, overlap: &overlap}
+	}
+
+	exp := Experiment{
+		Generator: &GeneratorConfig{MinVerticesNumber: 10, MaxVerticesNumber: 20, GraphDensity: 0.3},
+		Run:       RunConfig{RunsNumber: 40, Seed: 1, Workers: 8, Pinned: []string{"закреплённый"}},
+		Solvers:   []graph.Solver[string]{probe("свободный", false), probe("закреплённый", true), probe("второй", false)},
+	}
+
+	results, err := Collect(context.Background(), exp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 120 {
+		t.Fatalf("получено %d результатов, ожидалось 120", len(results))
+	}
+	if overlap.Load() {
+		t.Fatal("закреплённый метод выполнялся одновременно с другими методами")
+	}
+}
+
+func TestCollectIndependentOfWorkers(t *testing.T) {
+	registry := graph.DefaultRegistry[string]()
+	ils, err := registry.New(graph.ILSSolver, graph.Params{graph.IterationsParam: 50})
+	if err != nil {
+		t.Fatal(err)
+	}
+	solvers := append(newSolvers(t, graph.GreedySearchSolver, graph.BranchAndBoundSolver, graph.GRASPSolver), ils)
+
+	tests := []struct {
+		name string
+		exp  Experiment
+	}{
+		{"генератор", Experiment{
+			Generator: &GeneratorConfig{MinVerticesNumber: 15, MaxVerticesNumber: 25, GraphDensity: 0.3, Seed: 3},
+			Run:       RunConfig{RunsNumber: 6, Seed: 11},
+		}},
+		{"фиксированный граф", Experiment{
+			Generator: &GeneratorConfig{MinVerticesNumber: 20, MaxVerticesNumber: 20, GraphDensity: 0.2, Seed: 5},
+			Run:       RunConfig{RunsNumber: 6, Seed: 7, IsGraphFixed: true},
+		}},
+		{"серия", Experiment{
+			Generator: &GeneratorConfig{},
+			Sweep:     &Sweep{Vertices: []int{10, 20}, Densities: []float64{0.2, 0.5}},
+			Run:       RunConfig{RunsNumber: 3, Seed: 13},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serial := tt.exp
+			serial.Solvers = solvers
+			serial.Run.Workers = 1
+
+			parallel := serial
+			parallel.Run.Workers = 8
+			parallel.Run.Pinned = []string{graph.BranchAndBoundSolver}
+
+			want, err := Collect(context.Background(), serial)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := Collect(context.Background(), parallel)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(got) != len(want) || len(want) == 0 {
+				t.Fatalf("получено %d результатов, ожидалось %d", len(got), len(want))
+			}
+			for i := range want {
+				a, b := want[i], got[i]
+				if a.RunId != b.RunId || a.Method != b.Method || a.GraphSeed != b.GraphSeed || a.Seed != b.Seed {
+					t.Fatalf("результат %d: запуск %d %q (%d, %d), ожидался %d %q (%d, %d)", i, b.RunId, b.Method, b.GraphSeed, b.Seed, a.RunId, a.Method, a.GraphSeed, a.Seed)
+				}
+				if (a.Cell == nil) != (b.Cell == nil) || a.Cell != nil && *a.Cell != *b.Cell {
+					t.Fatalf("результат %d: ячейка %v, ожидалась %v", i, b.Cell, a.Cell)
+				}
+				if !slices.Equal(a.Result, b.Result) || a.Referenced != b.Referenced || a.Referenced && a.Quality != b.Quality {
+					t.Fatalf("результат %d (%s, запуск %d): решения различаются", i, a.Method, a.RunId)
+				}
+			}
+		})
+	}
+}
+
+func TestCollectWithoutExactReference(t *testing.T) {
+	registry := graph.DefaultRegistry[string]()
+	exact, err := registry.New(graph.BranchAndBoundSolver, graph.Params{graph.BudgetParam: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exp := Experiment{
+		Generator: &GeneratorConfig{MinVerticesNumber: 80, MaxVerticesNumber: 80, GraphDensity: 0.1, Seed: 1},
+		Run:       RunConfig{RunsNumber: 2, Seed: 1},
+		Solvers:   append([]graph.Solver[string]{exact}, newSolvers(t, graph.GreedySearchSolver)...),
+	}
+
+	results, err := Collect(context.Background(), exp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, res := range results {
+		if res.Exact && res.Optimal {
+			t.Fatal("точный метод не должен успеть доказать оптимум")
+		}
+		if !math.IsNaN(res.F1Factor) || res.Referenced {
+			t.Fatalf("%s: F1 = %g без эталона", res.Method, res.F1Factor)
+		}
+	}
+}
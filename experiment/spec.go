@@ -0,0 +1,474 @@
+package experiment
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+
+	"graphmis/graph"
+)
+
+const SpecVersion = 1
+
+type Spec struct {
+	Version    int           `yaml:"version" toml:"version" json:"version"`
+	Name       string        `yaml:"name,omitempty" toml:"name,omitempty" json:"name,omitempty"`
+	Graphs     []GraphSource `yaml:"graphs" toml:"graphs" json:"graphs"`
+	Methods    []MethodSpec  `yaml:"methods" toml:"methods" json:"methods"`
+	Runs       int           `yaml:"runs" toml:"runs" json:"runs"`
+	FixedGraph bool          `yaml:"fixed_graph,omitempty" toml:"fixed_graph,omitempty" json:"fixed_graph,omitempty"`
+	Seed       int64         `yaml:"seed,omitempty" toml:"seed,omitempty" json:"seed,omitempty"`
+	TimeLimit  int           `yaml:"time_limit,omitempty" toml:"time_limit,omitempty" json:"time_limit,omitempty"`
+	Workers    int           `yaml:"workers,omitempty" toml:"workers,omitempty" json:"workers,omitempty"`
+	Pinned     []string      `yaml:"pinned,omitempty" toml:"pinned,omitempty" json:"pinned,omitempty"`
+}
+
+type GraphSource struct {
+	Dot       string         `yaml:"dot,omitempty" toml:"dot,omitempty" json:"dot,omitempty"`
+	Dir       string         `yaml:"dir,omitempty" toml:"dir,omitempty" json:"dir,omitempty"`
+	Generator *GeneratorSpec `yaml:"generator,omitempty" toml:"generator,omitempty" json:"generator,omitempty"`
+	Sweep     *SweepSpec     `yaml:"sweep,omitempty" toml:"sweep,omitempty" json:"sweep,omitempty"`
+}
+
+type GeneratorSpec struct {
+	Name        string  `yaml:"name,omitempty" toml:"name,omitempty" json:"name,omitempty"`
+	Type        string  `yaml:"type,omitempty" toml:"type,omitempty" json:"type,omitempty"`
+	MinVertices int     `yaml:"min_vertices" toml:"min_vertices" json:"min_vertices"`
+	MaxVertices int     `yaml:"max_vertices" toml:"max_vertices" json:"max_vertices"`
+	Density     float64 `yaml:"density" toml:"density" json:"density"`
+	Seed        int64   `yaml:"seed,omitempty" toml:"seed,omitempty" json:"seed,omitempty"`
+}
+
+type SweepSpec struct {
+	Name           string     `yaml:"name,omitempty" toml:"name,omitempty" json:"name,omitempty"`
+	Type           string     `yaml:"type,omitempty" toml:"type,omitempty" json:"type,omitempty"`
+	Vertices       []int      `yaml:"vertices,omitempty" toml:"vertices,omitempty" json:"vertices,omitempty"`
+	VerticesRange  []int      `yaml:"vertices_range,omitempty" toml:"vertices_range,omitempty" json:"vertices_range,omitempty"`
+	Densities      []float64  `yaml:"densities,omitempty" toml:"densities,omitempty" json:"densities,omitempty"`
+	DensitiesRange []float64  `yaml:"densities_range,omitempty" toml:"densities_range,omitempty" json:"densities_range,omitempty"`
+	Cells          []CellSpec `yaml:"cells,omitempty" toml:"cells,omitempty" json:"cells,omitempty"`
+}
+
+type CellSpec struct {
+	Vertices int     `yaml:"vertices" toml:"vertices" json:"vertices"`
+	Density  float64 `yaml:"density" toml:"density" json:"density"`
+}
+
+type MethodSpec struct {
+	Name   string         `yaml:"name" toml:"name" json:"name"`
+	Params map[string]any `yaml:"params,omitempty" toml:"params,omitempty" json:"params,omitempty"`
+}
+
+type FieldError struct {
+	Field string
+	Err   error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Field, e.Err)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+func fieldError(field string, format string, args ...any) error {
+	return &FieldError{Field: field, Err: fmt.Errorf(format, args...)}
+}
+
+func LoadSpec(path string) (*Spec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var spec Spec
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		err = decoder.Decode(&spec)
+	case ".toml":
+		var md toml.MetaData
+		md, err = toml.NewDecoder(bytes.NewReader(data)).Decode(&spec)
+		if undecoded := md.Undecoded(); err == nil && len(undecoded) > 0 {
+			err = fieldError(undecoded[0].String(), "неизвестное поле")
+		}
+	case ".json":
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(&spec)
+	default:
+		return nil, fmt.Errorf("%s: неизвестный формат файла, ожидается .yaml, .toml или .json", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &spec, nil
+}
+
+func SaveSpec(path string, spec *Spec) error {
+	saved := *spec
+	saved.Version = SpecVersion
+
+	var buf bytes.Buffer
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		encoder := yaml.NewEncoder(&buf)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(&saved); err != nil {
+			return err
+		}
+	case ".toml":
+		if err := toml.NewEncoder(&buf).Encode(&saved); err != nil {
+			return err
+		}
+	case ".json":
+		encoder := json.NewEncoder(&buf)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(&saved); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("%s: неизвестный формат файла, ожидается .yaml, .toml или .json", path)
+	}
+	return os.WriteFile(path, buf.Bytes(), 0644)
+}
+
+func (s *Spec) Validate(registry *graph.Registry[string]) error {
+	var errs []error
+
+	switch {
+	case s.Version == 0:
+		errs = append(errs, fieldError("version", "версия формата обязательна"))
+	case s.Version < 1 || s.Version > SpecVersion:
+		errs = append(errs, fieldError("version", "неподдерживаемая версия %d", s.Version))
+	}
+	if s.Runs <= 0 {
+		errs = append(errs, fieldError("runs", "число запусков должно быть положительным"))
+	}
+	if s.TimeLimit < 0 {
+		errs = append(errs, fieldError("time_limit", "ограничение времени не может быть отрицательным"))
+	}
+	if s.Workers < 0 {
+		errs = append(errs, fieldError("workers", "число потоков не может быть отрицательным"))
+	}
+	for i, name := range s.Pinned {
+		if !slices.ContainsFunc(s.Methods, func(m MethodSpec) bool { return m.Name == name }) {
+			errs = append(errs, fieldError(fmt.Sprintf("pinned[%d]", i), "метод %q не выбран в methods", name))
+		}
+	}
+
+	if len(s.Graphs) == 0 {
+		errs = append(errs, fieldError("graphs", "не задан ни один источник графов"))
+	}
+	for i, source := range s.Graphs {
+		field := fmt.Sprintf("graphs[%d]", i)
+		sources := 0
+		for _, set := range []bool{source.Dot != "", source.Dir != "", source.Generator != nil, source.Sweep != nil} {
+			if set {
+				sources++
+			}
+		}
+		if sources != 1 {
+			errs = append(errs, fieldError(field, "ожидается ровно одно из полей dot, dir, generator или sweep"))
+			continue
+		}
+		if source.Generator != nil {
+			if _, err := source.Generator.config(field + ".generator"); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		if source.Sweep != nil {
+			if _, _, err := source.Sweep.sweep(field + ".sweep"); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	if len(s.Methods) == 0 {
+		errs = append(errs, fieldError("methods", "не выбран ни один метод"))
+	}
+	for i, m := range s.Methods {
+		field := fmt.Sprintf("methods[%d]", i)
+		d, ok := registry.Lookup(m.Name)
+		if !ok {
+			errs = append(errs, fieldError(field+".name", "неизвестный метод %q", m.Name))
+			continue
+		}
+		params := make(graph.Params, len(m.Params))
+		valid := true
+		for _, name := range slices.Sorted(maps.Keys(m.Params)) {
+			idx := slices.IndexFunc(d.Params, func(p graph.ParamSpec) bool { return p.Name == name })
+			if idx < 0 {
+				errs = append(errs, fieldError(field+".params."+name, "неизвестный параметр метода %q", m.Name))
+				valid = false
+				continue
+			}
+			value, err := d.Params[idx].Normalize(m.Params[name])
+			if err != nil {
+				errs = append(errs, &FieldError{Field: field + ".params." + name, Err: err})
+				valid = false
+				continue
+			}
+			params[name] = value
+		}
+		if valid && d.Check != nil {
+			if err := d.Check(params); err != nil {
+				errs = append(errs, &FieldError{Field: field + ".params", Err: err})
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (s *Spec) Experiments(registry *graph.Registry[string], baseDir string) ([]Experiment, error) {
+	if err := s.Validate(registry); err != nil {
+		return nil, err
+	}
+
+	solvers := make([]graph.Solver[string], 0, len(s.Methods))
+	for i, m := range s.Methods {
+		params := make(graph.Params, len(m.Params)+1)
+		for name, value := range m.Params {
+			params[name] = value
+		}
+		if _, ok := params[graph.TimeLimitParam]; !ok && s.TimeLimit > 0 {
+			if d, ok := registry.Lookup(m.Name); ok && slices.ContainsFunc(d.Params, func(p graph.ParamSpec) bool { return p.Name == graph.TimeLimitParam }) {
+				params[graph.TimeLimitParam] = s.TimeLimit
+			}
+		}
+
+		solver, err := registry.New(m.Name, params)
+		if err != nil {
+			return nil, &FieldError{Field: fmt.Sprintf("methods[%d]", i), Err: err}
+		}
+		solvers = append(solvers, solver)
+	}
+
+	run := RunConfig{
+		IsGraphFixed: s.FixedGraph,
+		RunsNumber:   s.Runs,
+		Seed:         s.Seed,
+		Workers:      s.Workers,
+		Pinned:       slices.Clone(s.Pinned),
+	}
+
+	var experiments []Experiment
+	for i, source := range s.Graphs {
+		field := fmt.Sprintf("graphs[%d]", i)
+		if source.Generator != nil {
+			generator, err := source.Generator.config(field + ".generator")
+			if err != nil {
+				return nil, err
+			}
+			name := source.Generator.Name
+			if name == "" {
+				name = fmt.Sprintf("generator_%d", i+1)
+			}
+			experiments = append(experiments, Experiment{Name: name, Generator: generator, Run: run, Solvers: solvers})
+			continue
+		}
+		if source.Sweep != nil {
+			sweep, gt, err := source.Sweep.sweep(field + ".sweep")
+			if err != nil {
+				return nil, err
+			}
+			name := source.Sweep.Name
+			if name == "" {
+				name = fmt.Sprintf("sweep_%d", i+1)
+			}
+			experiments = append(experiments, Experiment{Name: name, Generator: &GeneratorConfig{GraphType: gt}, Sweep: sweep, Run: run, Solvers: solvers})
+			continue
+		}
+
+		paths, err := source.dotFiles(baseDir, field)
+		if err != nil {
+			return nil, err
+		}
+		for _, path := range paths {
+			g, err := graph.LoadFromDot(path)
+			if err != nil {
+				return nil, &FieldError{Field: field, Err: fmt.Errorf("%s: %w", path, err)}
+			}
+			fixed := run
+			fixed.IsGraphFixed = true
+			name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+			experiments = append(experiments, Experiment{Name: name, Path: path, Graph: g, Run: fixed, Solvers: solvers})
+		}
+	}
+	return experiments, nil
+}
+
+func (s *Spec) ApplyTimeLimit(limit time.Duration) {
+	s.TimeLimit = int(limit.Milliseconds())
+	for i := range s.Methods {
+		delete(s.Methods[i].Params, graph.TimeLimitParam)
+	}
+}
+
+func GeneratorSpecOf(c GeneratorConfig) *GeneratorSpec {
+	return &GeneratorSpec{
+		Type:        graphTypeName(c.GraphType),
+		MinVertices: c.MinVerticesNumber,
+		MaxVertices: c.MaxVerticesNumber,
+		Density:     c.GraphDensity,
+		Seed:        c.Seed,
+	}
+}
+
+func SweepSpecOf(gt graph.GraphType, s Sweep) *SweepSpec {
+	spec := &SweepSpec{
+		Type:      graphTypeName(gt),
+		Vertices:  s.Vertices,
+		Densities: s.Densities,
+	}
+	for _, c := range s.Cells {
+		spec.Cells = append(spec.Cells, CellSpec{Vertices: c.Vertices, Density: c.Density})
+	}
+	return spec
+}
+
+func MethodSpecOf(solver graph.Solver[string]) MethodSpec {
+	config := solver.Config()
+	params := make(map[string]any, len(config))
+	for _, p := range solver.Params() {
+		value, ok := config[p.Name]
+		if !ok {
+			continue
+		}
+		if d, ok := value.(time.Duration); ok {
+			value = int(d.Milliseconds())
+		}
+		params[p.Name] = value
+	}
+	return MethodSpec{Name: solver.Name(), Params: params}
+}
+
+func graphTypeName(gt graph.GraphType) string {
+	if gt == graph.Directed {
+		return "directed"
+	}
+	return "undirected"
+}
+
+func parseGraphType(field, name string) (graph.GraphType, error) {
+	switch strings.ToLower(name) {
+	case "", "undirected":
+		return graph.Undirected, nil
+	case "directed":
+		return graph.Directed, nil
+	}
+	return graph.Undirected, fieldError(field, "неизвестный тип графа %q, ожидается undirected или directed", name)
+}
+
+func (g *GeneratorSpec) config(field string) (*GeneratorConfig, error) {
+	gt, err := parseGraphType(field+".type", g.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	if g.MinVertices <= 0 {
+		return nil, fieldError(field+".min_vertices", "минимум вершин должен быть положительным")
+	}
+	if g.MaxVertices < g.MinVertices {
+		return nil, fieldError(field+".max_vertices", "максимум вершин должен быть не меньше минимума")
+	}
+	if g.Density < 0 || g.Density > 1 {
+		return nil, fieldError(field+".density", "плотность графа должна быть в пределах [0; 1]")
+	}
+
+	return &GeneratorConfig{
+		GraphType:         gt,
+		MinVerticesNumber: g.MinVertices,
+		MaxVerticesNumber: g.MaxVertices,
+		GraphDensity:      g.Density,
+		Seed:              g.Seed,
+	}, nil
+}
+
+func (s *SweepSpec) sweep(field string) (*Sweep, graph.GraphType, error) {
+	gt, err := parseGraphType(field+".type", s.Type)
+	if err != nil {
+		return nil, gt, err
+	}
+
+	sweep := &Sweep{Vertices: slices.Clone(s.Vertices), Densities: slices.Clone(s.Densities)}
+	if s.VerticesRange != nil {
+		if len(s.VerticesRange) != 3 {
+			return nil, gt, fieldError(field+".vertices_range", "ожидается [начало, конец, шаг]")
+		}
+		values, err := IntRange(s.VerticesRange[0], s.VerticesRange[1], s.VerticesRange[2])
+		if err != nil {
+			return nil, gt, &FieldError{Field: field + ".vertices_range", Err: err}
+		}
+		sweep.Vertices = append(sweep.Vertices, values...)
+	}
+	if s.DensitiesRange != nil {
+		if len(s.DensitiesRange) != 3 {
+			return nil, gt, fieldError(field+".densities_range", "ожидается [начало, конец, шаг]")
+		}
+		values, err := FloatRange(s.DensitiesRange[0], s.DensitiesRange[1], s.DensitiesRange[2])
+		if err != nil {
+			return nil, gt, &FieldError{Field: field + ".densities_range", Err: err}
+		}
+		sweep.Densities = append(sweep.Densities, values...)
+	}
+	for _, c := range s.Cells {
+		sweep.Cells = append(sweep.Cells, Cell{Vertices: c.Vertices, Density: c.Density})
+	}
+
+	if len(sweep.Cells) > 0 && (len(sweep.Vertices) > 0 || len(sweep.Densities) > 0) {
+		return nil, gt, fieldError(field, "ячейки cells нельзя сочетать со списками вершин и плотностей")
+	}
+	if len(sweep.Cells) == 0 {
+		if len(sweep.Vertices) == 0 {
+			return nil, gt, fieldError(field+".vertices", "не задано ни одного значения числа вершин")
+		}
+		if len(sweep.Densities) == 0 {
+			return nil, gt, fieldError(field+".densities", "не задано ни одного значения плотности")
+		}
+	}
+	if err := sweep.Validate(); err != nil {
+		return nil, gt, &FieldError{Field: field, Err: err}
+	}
+	return sweep, gt, nil
+}
+
+func (g GraphSource) dotFiles(baseDir, field string) ([]string, error) {
+	resolve := func(path string) string {
+		if filepath.IsAbs(path) {
+			return path
+		}
+		return filepath.Join(baseDir, path)
+	}
+
+	if g.Dot != "" {
+		path := resolve(g.Dot)
+		if _, err := os.Stat(path); err != nil {
+			return nil, &FieldError{Field: field + ".dot", Err: err}
+		}
+		return []string{path}, nil
+	}
+
+	matches, err := filepath.Glob(filepath.Join(resolve(g.Dir), "*.dot"))
+	if err != nil {
+		return nil, &FieldError{Field: field + ".dir", Err: err}
+	}
+	if len(matches) == 0 {
+		return nil, fieldError(field+".dir", "в каталоге %s не найдено ни одного .dot файла", g.Dir)
+	}
+	slices.Sort(matches)
+	return matches, nil
+}
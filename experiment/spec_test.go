@@ -0,0 +1,169 @@
+package experiment
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+
+	"graphmis/graph"
+)
+
+func validSpec() *Spec {
+	return &Spec{
+		Version: SpecVersion,
+		Name:    "проверка",
+		Graphs: []GraphSource{
+			{Generator: &GeneratorSpec{MinVertices: 5, MaxVertices: 10, Density: 0.3, Seed: 2}},
+			{Sweep: &SweepSpec{Vertices: []int{10, 20}, Densities: []float64{0.1, 0.5}}},
+		},
+		Methods: []MethodSpec{
+			{Name: graph.BranchAndBoundSolver},
+			{Name: graph.ILSSolver, Params: map[string]any{graph.IterationsParam: 10}},
+		},
+		Runs:    3,
+		Seed:    7,
+		Workers: 2,
+		Pinned:  []string{graph.BranchAndBoundSolver},
+	}
+}
+
+func errorFields(err error) []string {
+	var fields []string
+	var walk func(err error)
+	walk = func(err error) {
+		if joined, ok := err.(interface{ Unwrap() []error }); ok {
+			for _, e := range joined.Unwrap() {
+				walk(e)
+			}
+			return
+		}
+		var fe *FieldError
+		if errors.As(err, &fe) {
+			fields = append(fields, fe.Field)
+		}
+	}
+	walk(err)
+	return fields
+}
+
+func TestSpecValidate(t *testing.T) {
+	registry := graph.DefaultRegistry[string]()
+
+	if err := validSpec().Validate(registry); err != nil {
+		t.Fatalf("корректное описание отклонено: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(s *Spec)
+		fields []string
+	}{
+		{"без версии", func(s *Spec) { s.Version = 0 }, []string{"version"}},
+		{"отрицательная версия", func(s *Spec) { s.Version = -1 }, []string{"version"}},
+		{"будущая версия", func(s *Spec) { s.Version = SpecVersion + 1 }, []string{"version"}},
+		{"нет запусков", func(s *Spec) { s.Runs = 0 }, []string{"runs"}},
+		{"отрицательное время", func(s *Spec) { s.TimeLimit = -1 }, []string{"time_limit"}},
+		{"отрицательное число потоков", func(s *Spec) { s.Workers = -1 }, []string{"workers"}},
+		{"закреплён невыбранный метод", func(s *Spec) { s.Pinned = []string{graph.MaghoutSolver} }, []string{"pinned[0]"}},
+		{"нет графов", func(s *Spec) { s.Graphs = nil }, []string{"graphs"}},
+		{"два источника", func(s *Spec) { s.Graphs[0].Dot = "a.dot" }, []string{"graphs[0]"}},
+		{"минимум вершин", func(s *Spec) { s.Graphs[0].Generator.MinVertices = 0 }, []string{"graphs[0].generator.min_vertices"}},
+		{"плотность", func(s *Spec) { s.Graphs[0].Generator.Density = 2 }, []string{"graphs[0].generator.density"}},
+		{"серия без плотностей", func(s *Spec) { s.Graphs[1].Sweep.Densities = nil }, []string{"graphs[1].sweep.densities"}},
+		{"нет методов", func(s *Spec) { s.Methods = nil; s.Pinned = nil }, []string{"methods"}},
+		{"неизвестный метод", func(s *Spec) { s.Methods[1].Name = "нет такого" }, []string{"methods[1].name"}},
+		{"неизвестный параметр", func(s *Spec) { s.Methods[1].Params["нет"] = 1 }, []string{"methods[1].params.нет"}},
+		{"неверный тип параметра", func(s *Spec) { s.Methods[1].Params[graph.IterationsParam] = "много" }, []string{"methods[1].params." + graph.IterationsParam}},
+		{"несовместимые параметры", func(s *Spec) {
+			s.Methods[0].Params = map[string]any{graph.EnumerateParam: true, graph.KernelizeParam: true}
+		}, []string{"methods[0].params"}},
+		{"несколько ошибок", func(s *Spec) { s.Version = 0; s.Runs = -1; s.Methods[1].Name = "" }, []string{"version", "runs", "methods[1].name"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := validSpec()
+			tt.modify(spec)
+			err := spec.Validate(registry)
+			if got := errorFields(err); !slices.Equal(got, tt.fields) {
+				t.Fatalf("ошибки в полях %q, ожидалось %q: %v", got, tt.fields, err)
+			}
+		})
+	}
+}
+
+func TestSpecRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	want := validSpec()
+
+	for _, ext := range []string{".yaml", ".yml", ".toml", ".json"} {
+		t.Run(ext, func(t *testing.T) {
+			path := filepath.Join(dir, "spec"+ext)
+			saved := *want
+			saved.Version = 0
+			if err := SaveSpec(path, &saved); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := LoadSpec(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Version != SpecVersion {
+				t.Fatalf("сохранена версия %d, ожидалась %d", got.Version, SpecVersion)
+			}
+			if err := got.Validate(graph.DefaultRegistry[string]()); err != nil {
+				t.Fatal(err)
+			}
+
+			got.Methods[1].Params[graph.IterationsParam] = 10
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("после сохранения в %s получено %+v, ожидалось %+v", ext, got, want)
+			}
+		})
+	}
+
+	if err := SaveSpec(filepath.Join(dir, "spec.txt"), want); err == nil {
+		t.Fatal("сохранение в неизвестном формате не отклонено")
+	}
+}
+
+func TestLoadSpec(t *testing.T) {
+	spec, err := LoadSpec(filepath.Join("..", "examples", "experiment.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := spec.Validate(graph.DefaultRegistry[string]()); err != nil {
+		t.Fatalf("пример описания не прошёл проверку: %v", err)
+	}
+
+	dir := t.TempDir()
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"unknown.yaml", "version: 1\nruns: 1\nrepeats: 2\n"},
+		{"unknown.toml", "version = 1\nruns = 1\nrepeats = 2\n"},
+		{"unknown.json", `{"version": 1, "runs": 1, "repeats": 2}`},
+		{"broken.yaml", "version: [1\n"},
+		{"spec.txt", "version: 1\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, tt.name)
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := LoadSpec(path); err == nil {
+				t.Fatal("ожидалась ошибка загрузки")
+			}
+		})
+	}
+
+	if _, err := LoadSpec(filepath.Join(dir, "missing.yaml")); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("отсутствующий файл: %v", err)
+	}
+}
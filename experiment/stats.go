@@ -0,0 +1,139 @@
+package experiment
+
+import (
+	"math"
+	"slices"
+)
+
+type Summary struct {
+	Count  int
+	Mean   float64
+	Median float64
+	StdDev float64
+	Min    float64
+	Max    float64
+	CILow  float64
+	CIHigh float64
+}
+
+func Summarize(values []float64) Summary {
+	n := len(values)
+	if n == 0 {
+		nan := math.NaN()
+		return Summary{Mean: nan, Median: nan, StdDev: nan, Min: nan, Max: nan, CILow: nan, CIHigh: nan}
+	}
+
+	sorted := slices.Sorted(slices.Values(values))
+
+	var sum float64
+	for _, v := range sorted {
+		sum += v
+	}
+	mean := sum / float64(n)
+
+	median := sorted[n/2]
+	if n%2 == 0 {
+		median = (sorted[n/2-1] + sorted[n/2]) / 2
+	}
+
+	s := Summary{
+		Count:  n,
+		Mean:   mean,
+		Median: median,
+		Min:    sorted[0],
+		Max:    sorted[n-1],
+		CILow:  mean,
+		CIHigh: mean,
+	}
+
+	if n > 1 {
+		var sq float64
+		for _, v := range sorted {
+			sq += (v - mean) * (v - mean)
+		}
+		s.StdDev = math.Sqrt(sq / float64(n-1))
+
+		margin := studentTQuantile(0.975, float64(n-1)) * s.StdDev / math.Sqrt(float64(n))
+		s.CILow = mean - margin
+		s.CIHigh = mean + margin
+	}
+	return s
+}
+
+func studentTCDF(t, df float64) float64 {
+	x := df / (df + t*t)
+	tail := 0.5 * regularizedBeta(x, df/2, 0.5)
+	if t > 0 {
+		return 1 - tail
+	}
+	return tail
+}
+
+func studentTQuantile(p, df float64) float64 {
+	low, high := -1e3, 1e3
+	for range 200 {
+		mid := (low + high) / 2
+		if studentTCDF(mid, df) < p {
+			low = mid
+		} else {
+			high = mid
+		}
+	}
+	return (low + high) / 2
+}
+
+func regularizedBeta(x, a, b float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	if x >= 1 {
+		return 1
+	}
+
+	lbeta, _ := math.Lgamma(a + b)
+	la, _ := math.Lgamma(a)
+	lb, _ := math.Lgamma(b)
+	front := math.Exp(lbeta - la - lb + a*math.Log(x) + b*math.Log(1-x))
+
+	if x < (a+1)/(a+b+2) {
+		return front * betaFraction(x, a, b) / a
+	}
+	return 1 - front*betaFraction(1-x, b, a)/b
+}
+
+func betaFraction(x, a, b float64) float64 {
+	const (
+		eps  = 1e-14
+		tiny = 1e-300
+	)
+
+	c, d := 1.0, 1-(a+b)*x/(a+1)
+	if math.Abs(d) < tiny {
+		d = tiny
+	}
+	d = 1 / d
+	h := d
+
+	for m := 1; m <= 300; m++ {
+		fm := float64(m)
+		for _, num := range []float64{
+			fm * (b - fm) * x / ((a + 2*fm - 1) * (a + 2*fm)),
+			-(a + fm) * (a + b + fm) * x / ((a + 2*fm) * (a + 2*fm + 1)),
+		} {
+			d = 1 + num*d
+			if math.Abs(d) < tiny {
+				d = tiny
+			}
+			c = 1 + num/c
+			if math.Abs(c) < tiny {
+				c = tiny
+			}
+			d = 1 / d
+			h *= d * c
+		}
+		if math.Abs(d*c-1) < eps {
+			break
+		}
+	}
+	return h
+}
@@ -0,0 +1,67 @@
+package experiment
+
+import (
+	"math"
+	"testing"
+)
+
+func assertClose(t *testing.T, name string, got, want, tolerance float64) {
+	t.Helper()
+	if math.Abs(got-want) > tolerance {
+		t.Errorf("%s = %.6g, ожидалось %.6g", name, got, want)
+	}
+}
+
+func TestStudentTQuantile(t *testing.T) {
+	tests := []struct {
+		p, df, want float64
+	}{
+		{0.975, 1, 12.7062},
+		{0.975, 9, 2.2622},
+		{0.975, 30, 2.0423},
+		{0.5, 5, 0},
+	}
+
+	for _, tt := range tests {
+		assertClose(t, "t-квантиль", studentTQuantile(tt.p, tt.df), tt.want, 1e-3)
+	}
+}
+
+func TestStudentTCDF(t *testing.T) {
+	tests := []struct {
+		t, df, want float64
+	}{
+		{0, 4, 0.5},
+		{2.2622, 9, 0.975},
+		{-2.2622, 9, 0.025},
+		{12.7062, 1, 0.975},
+	}
+
+	for _, tt := range tests {
+		assertClose(t, "функция распределения", studentTCDF(tt.t, tt.df), tt.want, 1e-4)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	s := Summarize([]float64{7, 3, 10, 1, 5, 9, 2, 8, 4, 6})
+
+	if s.Count != 10 {
+		t.Fatalf("Count = %d, ожидалось 10", s.Count)
+	}
+	assertClose(t, "Mean", s.Mean, 5.5, 1e-9)
+	assertClose(t, "Median", s.Median, 5.5, 1e-9)
+	assertClose(t, "StdDev", s.StdDev, 3.02765, 1e-5)
+	assertClose(t, "Min", s.Min, 1, 0)
+	assertClose(t, "Max", s.Max, 10, 0)
+	assertClose(t, "CILow", s.CILow, 3.33415, 1e-3)
+	assertClose(t, "CIHigh", s.CIHigh, 7.66585, 1e-3)
+
+	single := Summarize([]float64{4})
+	if single.StdDev != 0 || single.CILow != 4 || single.CIHigh != 4 {
+		t.Errorf("одно значение: %+v", single)
+	}
+
+	if empty := Summarize(nil); !math.IsNaN(empty.Mean) || !math.IsNaN(empty.CILow) {
+		t.Errorf("пустая выборка: %+v", empty)
+	}
+}
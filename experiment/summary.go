@@ -0,0 +1,130 @@
+package experiment
+
+import (
+	"encoding/csv"
+	"io"
+	"math"
+	"strconv"
+)
+
+type MethodSummary struct {
+	Source       string
+	Cell         *Cell
+	Method       string
+	Runs         int
+	Time         Summary
+	Cardinality  Summary
+	Ratio        Summary
+	BoundGap     Summary
+	Compared     int
+	OptimalShare float64
+	Invalid      int
+	Interrupted  int
+}
+
+func Summaries(results []*Result) []MethodSummary {
+	type groupKey struct {
+		source string
+		cell   Cell
+		method string
+	}
+
+	type group struct {
+		summary     MethodSummary
+		times       []float64
+		cardinality []float64
+		ratios      []float64
+		boundGaps   []float64
+		matched     int
+	}
+
+	var order []groupKey
+	groups := make(map[groupKey]*group)
+	for _, res := range results {
+		key := groupKey{source: res.Source, method: res.Method}
+		if res.Cell != nil {
+			key.cell = *res.Cell
+		}
+
+		g, ok := groups[key]
+		if !ok {
+			g = &group{summary: MethodSummary{Source: res.Source, Cell: res.Cell, Method: res.Method}}
+			groups[key] = g
+			order = append(order, key)
+		}
+
+		g.summary.Runs++
+		if res.Interrupted {
+			g.summary.Interrupted++
+		}
+		if !res.Check.Valid() {
+			g.summary.Invalid++
+			continue
+		}
+		g.times = append(g.times, float64(res.Time))
+		g.cardinality = append(g.cardinality, float64(len(res.Result)))
+		g.boundGaps = append(g.boundGaps, float64(res.BoundGap))
+		if res.Referenced {
+			g.summary.Compared++
+			g.ratios = append(g.ratios, res.Quality.Ratio)
+			if res.Quality.Optimal {
+				g.matched++
+			}
+		}
+	}
+
+	summaries := make([]MethodSummary, 0, len(order))
+	for _, key := range order {
+		g := groups[key]
+		g.summary.Time = Summarize(g.times)
+		g.summary.Cardinality = Summarize(g.cardinality)
+		g.summary.Ratio = Summarize(g.ratios)
+		g.summary.BoundGap = Summarize(g.boundGaps)
+		g.summary.OptimalShare = math.NaN()
+		if g.summary.Compared > 0 {
+			g.summary.OptimalShare = float64(g.matched) / float64(g.summary.Compared)
+		}
+		summaries = append(summaries, g.summary)
+	}
+	return summaries
+}
+
+func WriteSummaryCSV(w io.Writer, summaries []MethodSummary) error {
+	writer := csv.NewWriter(w)
+
+	header := []string{"Источник", "n", "p", "Метод", "Запусков"}
+	for _, metric := range []string{"Время (нс)", "Мощность", "|S|/α", "Разрыв до верхней оценки"} {
+		for _, column := range []string{"среднее", "медиана", "ст. откл.", "мин", "макс", "95% ДИ от", "95% ДИ до"} {
+			header = append(header, metric+": "+column)
+		}
+	}
+	header = append(header, "Доля оптимальных", "Некорректных решений", "Прерванных запусков")
+	writer.Write(header)
+
+	for _, s := range summaries {
+		var n, p string
+		if s.Cell != nil {
+			n = strconv.Itoa(s.Cell.Vertices)
+			p = strconv.FormatFloat(s.Cell.Density, 'f', -1, 64)
+		}
+
+		row := []string{s.Source, n, p, s.Method, strconv.Itoa(s.Runs)}
+		for _, summary := range []Summary{s.Time, s.Cardinality, s.Ratio, s.BoundGap} {
+			for _, v := range []float64{summary.Mean, summary.Median, summary.StdDev, summary.Min, summary.Max, summary.CILow, summary.CIHigh} {
+				row = append(row, formatStat(v))
+			}
+		}
+		row = append(row, formatStat(s.OptimalShare), strconv.Itoa(s.Invalid), strconv.Itoa(s.Interrupted))
+		writer.Write(row)
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func formatStat(v float64) string {
+	if math.IsNaN(v) {
+		return ""
+	}
+	return strconv.FormatFloat(v, 'f', 4, 64)
+}
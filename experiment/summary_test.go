@@ -0,0 +1,84 @@
+package experiment
+
+import (
+	"bytes"
+	"encoding/csv"
+	"math"
+	"testing"
+
+	"graphmis/graph"
+)
+
+func TestSummaries(t *testing.T) {
+	invalid := graph.Verification[string]{Violations: []graph.Violation[string]{{Kind: graph.AdjacentVertices, Vertices: []string{"a", "b"}}}}
+	optimal := graph.Quality{Alpha: 3, Ratio: 1, Optimal: true}
+	suboptimal := graph.Quality{Alpha: 3, Ratio: 2.0 / 3, Gap: 1}
+	small, large := &Cell{Vertices: 10, Density: 0.1}, &Cell{Vertices: 20, Density: 0.1}
+
+	results := []*Result{
+		{Source: "серия", Cell: small, Method: "A", Time: 10, Result: []string{"a", "b", "c"}, BoundGap: 1, Referenced: true, Quality: optimal},
+		{Source: "серия", Cell: small, Method: "A", Time: 20, Result: []string{"a", "b"}, BoundGap: 2, Referenced: true, Quality: suboptimal},
+		{Source: "серия", Cell: small, Method: "A", Time: 30, Result: []string{"a", "b", "c"}, BoundGap: 1, Interrupted: true},
+		{Source: "серия", Cell: small, Method: "A", Time: 1000, Result: []string{"a", "b", "c", "d", "e"}, BoundGap: -1, Referenced: true, Quality: optimal, Check: invalid},
+		{Source: "серия", Cell: small, Method: "B", Time: 5, Result: []string{"a"}, BoundGap: 3, Interrupted: true, Check: invalid},
+		{Source: "серия", Cell: large, Method: "A", Time: 40, Result: []string{"a", "b", "c", "d"}, BoundGap: 0},
+		{Source: "граф", Method: "A", Time: 50, Result: []string{"a"}, BoundGap: 0},
+	}
+
+	summaries := Summaries(results)
+	if len(summaries) != 4 {
+		t.Fatalf("получено %d групп, ожидалось 4", len(summaries))
+	}
+
+	a := summaries[0]
+	if a.Method != "A" || a.Cell != small || a.Runs != 4 || a.Invalid != 1 || a.Interrupted != 1 {
+		t.Fatalf("группа A: %+v", a)
+	}
+	if a.Time.Count != 3 || a.Cardinality.Count != 3 || a.BoundGap.Count != 3 {
+		t.Fatalf("в статистику вошло %d/%d/%d запусков, ожидалось 3", a.Time.Count, a.Cardinality.Count, a.BoundGap.Count)
+	}
+	assertClose(t, "среднее время", a.Time.Mean, 20, 1e-9)
+	assertClose(t, "максимум времени", a.Time.Max, 30, 0)
+	assertClose(t, "средняя мощность", a.Cardinality.Mean, 8.0/3, 1e-9)
+	assertClose(t, "средний разрыв", a.BoundGap.Mean, 4.0/3, 1e-9)
+	if a.Compared != 2 || a.Ratio.Count != 2 {
+		t.Fatalf("сравнено %d запусков, ожидалось 2", a.Compared)
+	}
+	assertClose(t, "|S|/α", a.Ratio.Mean, 5.0/6, 1e-9)
+	assertClose(t, "доля оптимальных", a.OptimalShare, 0.5, 1e-9)
+
+	b := summaries[1]
+	if b.Method != "B" || b.Runs != 1 || b.Invalid != 1 || b.Interrupted != 1 || b.Time.Count != 0 {
+		t.Fatalf("группа B: %+v", b)
+	}
+	if !math.IsNaN(b.Time.Mean) || !math.IsNaN(b.Time.CILow) || !math.IsNaN(b.OptimalShare) {
+		t.Fatalf("группа без корректных запусков: %+v", b)
+	}
+
+	if summaries[2].Cell != large || summaries[3].Source != "граф" || summaries[3].Cell != nil {
+		t.Fatalf("порядок групп: %+v, %+v", summaries[2], summaries[3])
+	}
+
+	var buf bytes.Buffer
+	if err := WriteSummaryCSV(&buf, summaries); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 5 {
+		t.Fatalf("в CSV %d строк, ожидалось 5", len(rows))
+	}
+	header := rows[0]
+	if header[len(header)-2] != "Некорректных решений" || header[len(header)-1] != "Прерванных запусков" {
+		t.Fatalf("заголовок: %q", header)
+	}
+	row := rows[2]
+	if row[3] != "B" || row[4] != "1" || row[5] != "" || row[len(row)-2] != "1" || row[len(row)-1] != "1" {
+		t.Fatalf("строка B: %q", row)
+	}
+	if rows[1][5] != "20.0000" || rows[4][1] != "" {
+		t.Fatalf("строки: %q, %q", rows[1], rows[4])
+	}
+}
@@ -0,0 +1,81 @@
+package experiment
+
+import (
+	"fmt"
+	"math"
+)
+
+type Cell struct {
+	Vertices int
+	Density  float64
+}
+
+func (c Cell) String() string {
+	return fmt.Sprintf("n=%d, p=%g", c.Vertices, c.Density)
+}
+
+type Sweep struct {
+	Vertices  []int
+	Densities []float64
+	Cells     []Cell
+}
+
+func (s Sweep) Grid() []Cell {
+	if len(s.Cells) > 0 {
+		return s.Cells
+	}
+
+	cells := make([]Cell, 0, len(s.Vertices)*len(s.Densities))
+	for _, n := range s.Vertices {
+		for _, p := range s.Densities {
+			cells = append(cells, Cell{Vertices: n, Density: p})
+		}
+	}
+	return cells
+}
+
+func (s Sweep) Validate() error {
+	if len(s.Grid()) == 0 {
+		return fmt.Errorf("серия параметров не содержит ни одной ячейки")
+	}
+	for _, c := range s.Grid() {
+		if c.Vertices <= 0 {
+			return fmt.Errorf("%v: число вершин должно быть положительным", c)
+		}
+		if c.Density < 0 || c.Density > 1 {
+			return fmt.Errorf("%v: плотность графа должна быть в пределах [0; 1]", c)
+		}
+	}
+	return nil
+}
+
+func IntRange(from, to, step int) ([]int, error) {
+	if step <= 0 {
+		return nil, fmt.Errorf("шаг должен быть положительным")
+	}
+	if to < from {
+		return nil, fmt.Errorf("конец диапазона должен быть не меньше начала")
+	}
+
+	var values []int
+	for v := from; v <= to; v += step {
+		values = append(values, v)
+	}
+	return values, nil
+}
+
+func FloatRange(from, to, step float64) ([]float64, error) {
+	if step <= 0 {
+		return nil, fmt.Errorf("шаг должен быть положительным")
+	}
+	if to < from {
+		return nil, fmt.Errorf("конец диапазона должен быть не меньше начала")
+	}
+
+	count := int(math.Floor((to-from)/step+1e-9)) + 1
+	values := make([]float64, count)
+	for i := range values {
+		values[i] = math.Round((from+float64(i)*step)*1e9) / 1e9
+	}
+	return values, nil
+}
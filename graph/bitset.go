@@ -0,0 +1,123 @@
+package graph
+
+import "math/bits"
+
+const wordSize = 64
+
+type bitset []uint64
+
+func newBitset(size int) bitset {
+	return make(bitset, (size+wordSize-1)/wordSize)
+}
+
+func (b bitset) Has(i int) bool {
+	return b[i/wordSize]&(1<<(uint(i)%wordSize)) != 0
+}
+
+func (b bitset) Set(i int) {
+	b[i/wordSize] |= 1 << (uint(i) % wordSize)
+}
+
+func (b bitset) Clear(i int) {
+	b[i/wordSize] &^= 1 << (uint(i) % wordSize)
+}
+
+func (b bitset) Count() int {
+	count := 0
+	for _, w := range b {
+		count += bits.OnesCount64(w)
+	}
+	return count
+}
+
+func (b bitset) AndCount(other bitset) int {
+	count := 0
+	for i := range b {
+		count += bits.OnesCount64(b[i] & other[i])
+	}
+	return count
+}
+
+func (b bitset) Intersects(other bitset) bool {
+	for i := range b {
+		if b[i]&other[i] != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (b bitset) IsEmpty() bool {
+	for _, w := range b {
+		if w != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (b bitset) First() int {
+	for w, word := range b {
+		if word != 0 {
+			return w*wordSize + bits.TrailingZeros64(word)
+		}
+	}
+	return -1
+}
+
+func (b bitset) Clone() bitset {
+	c := make(bitset, len(b))
+	copy(c, b)
+	return c
+}
+
+func (b bitset) Equal(other bitset) bool {
+	for i := range b {
+		if b[i] != other[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (b bitset) IsSubsetOf(other bitset) bool {
+	for i := range b {
+		if b[i]&^other[i] != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (b bitset) Union(other bitset) bitset {
+	result := make(bitset, len(b))
+	for i := range b {
+		result[i] = b[i] | other[i]
+	}
+	return result
+}
+
+func (b bitset) And(other bitset) {
+	for i := range b {
+		b[i] &= other[i]
+	}
+}
+
+func (b bitset) AndNot(other bitset) {
+	for i := range b {
+		b[i] &^= other[i]
+	}
+}
+
+func (b bitset) ForEach(size int, fn func(i int)) {
+	for w, word := range b {
+		for word != 0 {
+			i := w*wordSize + bits.TrailingZeros64(word)
+			if i >= size {
+				return
+			}
+			fn(i)
+			word &= word - 1
+		}
+	}
+}
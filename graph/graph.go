@@ -11,6 +11,14 @@ const (
 	Undirected
 )
 
+type GraphBackend int
+
+const (
+	AutoBackend GraphBackend = iota
+	DenseBackend
+	SparseBackend
+)
+
 type EdgeOptions[T comparable] struct {
 	From   *T
 	To     *T
@@ -28,15 +36,19 @@ func (eo EdgeOptions[T]) String() string {
 type Graph[T comparable] interface {
 	Type() GraphType
 	Size() int
+	Backend() GraphBackend
 
 	HasLoop() bool
+	HasVertexWeights() bool
 	HasWeights() bool
 	IsAcyclic() bool
 
 	AddEdge(from, to *T, weight ...float64) bool
 	AddVertex(v *T) bool
 	ClearCache()
+	ClearVertexWeights()
 	ClearWeights()
+	Clone() Graph[T]
 	ContainsEdge(from, to *T) bool
 	ContainsVertex(v *T) bool
 	Dot(verticesToColor ...[]T) string
@@ -46,24 +58,34 @@ type Graph[T comparable] interface {
 	GetEdge(from, to *T) *EdgeOptions[T]
 	GetEdgesOf(v *T) []*EdgeOptions[T]
 	GetNeighbors(v *T) []T
+	GetVertexWeight(v *T) (float64, bool)
 	GetWeight(from, to *T) (float64, bool)
+	InducedSubgraph(vertices []T) Graph[T]
 	RemoveEdge(from, to *T) bool
 	RemoveVertex(v *T) bool
 	Reset()
+	SetVertexWeight(v *T, weight float64) bool
 	SetWeight(from, to *T, weight float64) bool
 }
 
-func NewGraph[T comparable](gt GraphType) Graph[T] {
+func NewGraph[T comparable](gt GraphType, backend ...GraphBackend) Graph[T] {
+	gb := AutoBackend
+	if len := len(backend); len != 0 {
+		gb = backend[len-1]
+	}
+
 	switch gt {
 	case Directed:
 		return &graph[T]{
 			gtype:         Directed,
+			backend:       gb,
 			adjList:       make(map[T][]T),
 			vertexToIndex: make(map[T]int),
 		}
 	case Undirected:
 		return &graph[T]{
 			gtype:         Undirected,
+			backend:       gb,
 			adjList:       make(map[T][]T),
 			vertexToIndex: make(map[T]int),
 		}
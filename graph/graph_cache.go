@@ -1,6 +1,9 @@
 package graph
 
-import "math"
+import (
+	"math"
+	"slices"
+)
 
 type graphMatrix[T comparable] interface {
 	Size() int
@@ -9,8 +12,9 @@ type graphMatrix[T comparable] interface {
 }
 
 type adjMatrix struct {
-	size int
-	data []bool
+	size  int
+	words int
+	data  []uint64
 }
 
 func (m *adjMatrix) Size() int {
@@ -18,22 +22,42 @@ func (m *adjMatrix) Size() int {
 }
 
 func (m *adjMatrix) Get(i, j int) bool {
-	return m.data[i*m.size+j]
+	return m.Row(i).Has(j)
 }
 
 func (m *adjMatrix) Set(i, j int, v bool) {
-	m.data[i*m.size+j] = v
+	if v {
+		m.Row(i).Set(j)
+	} else {
+		m.Row(i).Clear(j)
+	}
+}
+
+func (m *adjMatrix) Row(i int) bitset {
+	return bitset(m.data[i*m.words : (i+1)*m.words : (i+1)*m.words])
+}
+
+func (m *adjMatrix) Degree(i int) int {
+	return m.Row(i).Count()
+}
+
+func (m *adjMatrix) DegreeIn(i int, mask bitset) int {
+	return m.Row(i).AndCount(mask)
 }
 
 func newAdjMatrix(size int) *adjMatrix {
+	words := (size + wordSize - 1) / wordSize
 	return &adjMatrix{
-		size: size,
-		data: make([]bool, size*size),
+		size:  size,
+		words: words,
+		data:  make([]uint64, size*words),
 	}
 }
 
 var NO_WEIGHT = math.Inf(1)
 
+const DEFAULT_VERTEX_WEIGHT = 1.0
+
 type weightMatrix struct {
 	size int
 	data []float64
@@ -59,7 +83,60 @@ func newWeightMatrix(size int) *weightMatrix {
 
 }
 
+func growWeightMatrix(old *weightMatrix, size int) *weightMatrix {
+	matrix := newWeightMatrix(size)
+	for i := range matrix.data {
+		matrix.data[i] = NO_WEIGHT
+	}
+	for i := range old.size {
+		copy(matrix.data[i*size:i*size+old.size], old.data[i*old.size:(i+1)*old.size])
+	}
+	return matrix
+}
+
+type csrMatrix struct {
+	size    int
+	offsets []int
+	targets []int
+}
+
+func (m *csrMatrix) Size() int {
+	return m.size
+}
+
+func (m *csrMatrix) Row(i int) []int {
+	return m.targets[m.offsets[i]:m.offsets[i+1]]
+}
+
+func (m *csrMatrix) Degree(i int) int {
+	return m.offsets[i+1] - m.offsets[i]
+}
+
+func (m *csrMatrix) Get(i, j int) bool {
+	_, found := slices.BinarySearch(m.Row(i), j)
+	return found
+}
+
+func newCSRMatrix(rows [][]int) *csrMatrix {
+	offsets := make([]int, len(rows)+1)
+	for i, row := range rows {
+		slices.Sort(row)
+		rows[i] = slices.Compact(row)
+		offsets[i+1] = offsets[i] + len(rows[i])
+	}
+
+	targets := make([]int, 0, offsets[len(rows)])
+	for _, row := range rows {
+		targets = append(targets, row...)
+	}
+
+	return &csrMatrix{
+		size:    len(rows),
+		offsets: offsets,
+		targets: targets,
+	}
+}
+
 type graphCache struct {
-	AdjMatrix    *adjMatrix
 	WeightMatrix *weightMatrix
 }
@@ -0,0 +1,34 @@
+package graph
+
+func ConnectedComponents[T comparable](g Graph[T]) [][]T {
+	s := snapshotOf(g)
+	if s == nil {
+		return nil
+	}
+
+	n := s.Size()
+	csr := s.CSR()
+	visited := make([]bool, n)
+	var components [][]T
+
+	for start := range n {
+		if visited[start] {
+			continue
+		}
+
+		visited[start] = true
+		queue := []int{start}
+		for head := 0; head < len(queue); head++ {
+			for _, u := range csr.Row(queue[head]) {
+				if !visited[u] {
+					visited[u] = true
+					queue = append(queue, u)
+				}
+			}
+		}
+
+		components = append(components, s.Vertices(queue))
+	}
+
+	return components
+}
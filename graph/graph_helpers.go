@@ -12,37 +12,66 @@ import (
 	"github.com/awalterschulze/gographviz"
 )
 
-func initAdjMatrix[T comparable](g *graph[T]) {
-	matrix := newAdjMatrix(g.size)
+func initAdjMatrix(csr *csrMatrix) *adjMatrix {
+	matrix := newAdjMatrix(csr.Size())
 	var wg sync.WaitGroup
 
+	for i := range csr.Size() {
+		wg.Add(1)
+		go func(row bitset, neighbors []int) {
+			defer wg.Done()
+			for _, j := range neighbors {
+				row.Set(j)
+			}
+		}(matrix.Row(i), csr.Row(i))
+	}
+
+	wg.Wait()
+	return matrix
+}
+
+const denseVertexLimit = 4096
+
+func useDenseBackend[T comparable](g *graph[T]) bool {
+	switch g.backend {
+	case DenseBackend:
+		return true
+	case SparseBackend:
+		return false
+	}
+
+	if g.size <= denseVertexLimit {
+		return true
+	}
+
+	entries := 0
+	for _, neighbors := range g.adjList {
+		entries += len(neighbors)
+	}
+	return entries*wordSize >= g.size*g.size
+}
+
+func initCSR[T comparable](g *graph[T]) *csrMatrix {
+	rows := make([][]int, g.size)
+
 	for from, neighbors := range g.adjList {
 		fromIdx, ok := g.vertexToIndex[from]
 		if !ok {
 			continue
 		}
-		wg.Add(1)
-		go func(fromIdx int, neighbors []T) {
-			defer wg.Done()
-			for _, to := range neighbors {
-				toIdx, ok := g.vertexToIndex[to]
-				if !ok {
-					continue
-				}
-				if g.gtype == Directed {
-					matrix.Set(fromIdx, toIdx, true)
-				} else {
-					if fromIdx < toIdx {
-						matrix.Set(fromIdx, toIdx, true)
-						matrix.Set(toIdx, fromIdx, true)
-					}
-				}
+		for _, to := range neighbors {
+			toIdx, ok := g.vertexToIndex[to]
+			if !ok {
+				continue
+			}
+			rows[fromIdx] = append(rows[fromIdx], toIdx)
+			if g.gtype == Directed && fromIdx != toIdx {
+				rows[toIdx] = append(rows[toIdx], fromIdx)
 			}
-		}(fromIdx, neighbors)
+		}
 	}
 
-	wg.Wait()
-	g.cache.AdjMatrix = matrix
+	return newCSRMatrix(rows)
 }
 
 func initWeightMatrix[T comparable](g *graph[T]) *weightMatrix {
@@ -82,25 +111,6 @@ func checkForLoopsByAdjList[T comparable](adjList map[T][]T) bool {
 	return found.Load()
 }
 
-func checkForLoopsByAdjMatrix(adjMatrix adjMatrix) bool {
-	size := adjMatrix.Size()
-	var found atomic.Bool
-	wg := sync.WaitGroup{}
-
-	for i := range size {
-		wg.Add(1)
-		go func(i int) {
-			defer wg.Done()
-			if adjMatrix.Get(i, i) {
-				found.Store(true)
-			}
-		}(i)
-	}
-
-	wg.Wait()
-	return found.Load()
-}
-
 func checkForCycles[T comparable](g *graph[T]) bool {
 	if g.size == 0 {
 		return false
@@ -254,6 +264,12 @@ func LoadFromDot(path string) (Graph[string], error) {
 		v := origToValue[orig]
 		vCopy := v
 		g.AddVertex(&vCopy)
+
+		if wstr, ok := nodeMap[orig].Attrs["weight"]; ok {
+			if w, err := strconv.ParseFloat(wstr, 64); err == nil {
+				g.SetVertexWeight(&vCopy, w)
+			}
+		}
 	}
 
 	for _, edge := range gmap.Edges.Edges {
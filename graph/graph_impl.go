@@ -3,20 +3,27 @@ package graph
 import (
 	"bytes"
 	"fmt"
+	"maps"
 	"os"
 	"slices"
 	"sync"
+	"sync/atomic"
 )
 
 type graph[T comparable] struct {
 	Graph[T]
 
-	gtype GraphType
-	size  int
+	mu sync.RWMutex
 
-	adjList map[T][]T
+	gtype   GraphType
+	backend GraphBackend
+	size    int
+
+	adjList       map[T][]T
+	vertexWeights map[T]float64
 
 	cache graphCache
+	snap  atomic.Pointer[snapshot[T]]
 
 	hasLoop   *bool
 	isAcyclic *bool
@@ -30,10 +37,26 @@ func (g *graph[T]) Type() GraphType {
 }
 
 func (g *graph[T]) Size() int {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
 	return g.size
 }
 
+func (g *graph[T]) Backend() GraphBackend {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if useDenseBackend(g) {
+		return DenseBackend
+	}
+	return SparseBackend
+}
+
 func (g *graph[T]) HasLoop() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
 	if g.size == 0 {
 		return false
 	}
@@ -43,20 +66,29 @@ func (g *graph[T]) HasLoop() bool {
 	}
 
 	g.hasLoop = new(bool)
-	if g.cache.AdjMatrix != nil {
-		*g.hasLoop = checkForLoopsByAdjMatrix(*g.cache.AdjMatrix)
-	} else {
-		*g.hasLoop = checkForLoopsByAdjList(g.adjList)
-	}
+	*g.hasLoop = checkForLoopsByAdjList(g.adjList)
 
 	return *g.hasLoop
 }
 
+func (g *graph[T]) HasVertexWeights() bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return len(g.vertexWeights) != 0
+}
+
 func (g *graph[T]) HasWeights() bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
 	return g.cache.WeightMatrix != nil
 }
 
 func (g *graph[T]) IsAcyclic() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
 	if g.size == 0 {
 		return true
 	}
@@ -75,35 +107,31 @@ func (g *graph[T]) AddEdge(from, to *T, weight ...float64) bool {
 		return false
 	}
 
-	if g.ContainsEdge(from, to) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.containsEdge(from, to) {
 		return false
 	}
 
-	if !g.ContainsVertex(from) {
-		g.AddVertex(from)
+	if !g.containsVertex(from) {
+		g.addVertex(from)
 	}
 
-	if !g.ContainsVertex(to) {
-		g.AddVertex(to)
+	if !g.containsVertex(to) {
+		g.addVertex(to)
 	}
 
 	g.adjList[*from] = append(g.adjList[*from], *to)
-	if g.gtype == Undirected {
+	if g.gtype == Undirected && *from != *to {
 		g.adjList[*to] = append(g.adjList[*to], *from)
 	}
 
-	if g.cache.AdjMatrix != nil {
-		fromIdx := g.vertexToIndex[*from]
-		toIdx := g.vertexToIndex[*to]
-		g.cache.AdjMatrix.Set(fromIdx, toIdx, true)
-		if g.gtype == Undirected {
-			g.cache.AdjMatrix.Set(toIdx, fromIdx, true)
-		}
-	}
+	g.snap.Store(nil)
 
 	if len := len(weight); len != 0 {
 		if g.cache.WeightMatrix == nil {
-			initWeightMatrix(g)
+			g.cache.WeightMatrix = initWeightMatrix(g)
 		}
 		fromIdx := g.vertexToIndex[*from]
 		toIdx := g.vertexToIndex[*to]
@@ -129,7 +157,14 @@ func (g *graph[T]) AddVertex(v *T) bool {
 		return false
 	}
 
-	if g.ContainsVertex(v) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.addVertex(v)
+}
+
+func (g *graph[T]) addVertex(v *T) bool {
+	if g.containsVertex(v) {
 		return false
 	}
 
@@ -140,14 +175,73 @@ func (g *graph[T]) AddVertex(v *T) bool {
 
 	g.size++
 
+	if g.cache.WeightMatrix != nil {
+		g.cache.WeightMatrix = growWeightMatrix(g.cache.WeightMatrix, g.size)
+	}
+
+	g.snap.Store(nil)
+
 	return true
 }
 
+func (g *graph[T]) Clone() Graph[T] {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	clone := &graph[T]{
+		gtype:         g.gtype,
+		backend:       g.backend,
+		size:          g.size,
+		adjList:       make(map[T][]T, len(g.adjList)),
+		vertexWeights: maps.Clone(g.vertexWeights),
+		indexToVertex: slices.Clone(g.indexToVertex),
+		vertexToIndex: maps.Clone(g.vertexToIndex),
+	}
+
+	for v, neighbors := range g.adjList {
+		clone.adjList[v] = slices.Clone(neighbors)
+	}
+
+	if g.cache.WeightMatrix != nil {
+		clone.cache.WeightMatrix = &weightMatrix{
+			size: g.cache.WeightMatrix.size,
+			data: slices.Clone(g.cache.WeightMatrix.data),
+		}
+	}
+
+	if g.hasLoop != nil {
+		clone.hasLoop = new(bool)
+		*clone.hasLoop = *g.hasLoop
+	}
+
+	if g.isAcyclic != nil {
+		clone.isAcyclic = new(bool)
+		*clone.isAcyclic = *g.isAcyclic
+	}
+
+	return clone
+}
+
 func (g *graph[T]) ClearCache() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
 	g.cache = graphCache{}
+	g.snap.Store(nil)
+}
+
+func (g *graph[T]) ClearVertexWeights() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.vertexWeights = nil
+	g.snap.Store(nil)
 }
 
 func (g *graph[T]) ClearWeights() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
 	g.cache.WeightMatrix = nil
 }
 
@@ -156,6 +250,13 @@ func (g *graph[T]) ContainsEdge(from, to *T) bool {
 		return false
 	}
 
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.containsEdge(from, to)
+}
+
+func (g *graph[T]) containsEdge(from, to *T) bool {
 	if _, ok := g.vertexToIndex[*from]; !ok {
 		return false
 	}
@@ -164,18 +265,7 @@ func (g *graph[T]) ContainsEdge(from, to *T) bool {
 		return false
 	}
 
-	if g.cache.AdjMatrix == nil {
-		if slices.Contains(g.adjList[*from], *to) {
-			return true
-		}
-	} else {
-		fromIdx := g.vertexToIndex[*from]
-		toIdx := g.vertexToIndex[*to]
-
-		return g.cache.AdjMatrix.Get(fromIdx, toIdx)
-	}
-
-	return false
+	return slices.Contains(g.adjList[*from], *to)
 }
 
 func (g *graph[T]) ContainsVertex(v *T) bool {
@@ -183,6 +273,13 @@ func (g *graph[T]) ContainsVertex(v *T) bool {
 		return false
 	}
 
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.containsVertex(v)
+}
+
+func (g *graph[T]) containsVertex(v *T) bool {
 	_, ok := g.vertexToIndex[*v]
 	return ok
 }
@@ -207,6 +304,9 @@ func (g *graph[T]) ExportToFile(path string, overwrite bool, verticesToColor ...
 }
 
 func (g *graph[T]) GetAllEdges() []*EdgeOptions[T] {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
 	resultChan := make(chan *EdgeOptions[T], 100)
 	var wg sync.WaitGroup
 
@@ -248,6 +348,13 @@ func (g *graph[T]) GetAllEdges() []*EdgeOptions[T] {
 }
 
 func (g *graph[T]) GetAllVertices() []T {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.getAllVertices()
+}
+
+func (g *graph[T]) getAllVertices() []T {
 	result := make([]T, len(g.indexToVertex))
 	copy(result, g.indexToVertex)
 	return result
@@ -258,20 +365,16 @@ func (g *graph[T]) GetEdge(from, to *T) *EdgeOptions[T] {
 		return nil
 	}
 
-	if g.cache.AdjMatrix == nil {
-		initAdjMatrix(g)
-	}
+	g.mu.RLock()
+	defer g.mu.RUnlock()
 
-	if !g.ContainsEdge(from, to) {
+	if !g.containsEdge(from, to) {
 		return nil
 	}
 
 	fromIdx := g.vertexToIndex[*from]
 	toIdx := g.vertexToIndex[*to]
 
-	if !g.cache.AdjMatrix.Get(fromIdx, toIdx) {
-		return nil
-	}
 	if g.cache.WeightMatrix != nil {
 		return &EdgeOptions[T]{
 			From:   from,
@@ -287,7 +390,14 @@ func (g *graph[T]) GetEdge(from, to *T) *EdgeOptions[T] {
 }
 
 func (g *graph[T]) GetEdgesOf(v *T) []*EdgeOptions[T] {
-	if v == nil || !g.ContainsVertex(v) {
+	if v == nil {
+		return nil
+	}
+
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if !g.containsVertex(v) {
 		return nil
 	}
 
@@ -338,53 +448,40 @@ func (g *graph[T]) GetEdgesOf(v *T) []*EdgeOptions[T] {
 }
 
 func (g *graph[T]) GetNeighbors(v *T) []T {
-	if v == nil || !g.ContainsVertex(v) {
+	if v == nil {
 		return nil
 	}
 
-	if g.cache.AdjMatrix == nil {
-		initAdjMatrix(g)
-	}
+	s := g.snapshot()
 
-	vIdx := g.vertexToIndex[*v]
-	resultChan := make(chan T, len(g.indexToVertex)*2)
-	var wg sync.WaitGroup
+	vIdx, ok := s.vertexToIndex[*v]
+	if !ok {
+		return nil
+	}
 
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		for i := range g.indexToVertex {
-			if g.cache.AdjMatrix.Get(i, vIdx) {
-				vertex := g.indexToVertex[i]
-				resultChan <- vertex
-			}
-		}
-	}()
+	return s.Vertices(s.CSR().Row(vIdx))
+}
 
-	if g.gtype == Directed {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			for i := range g.indexToVertex {
-				if g.cache.AdjMatrix.Get(vIdx, i) {
-					vertex := g.indexToVertex[i]
-					resultChan <- vertex
-				}
-			}
-		}()
+func (g *graph[T]) GetVertexWeight(v *T) (float64, bool) {
+	if v == nil {
+		return DEFAULT_VERTEX_WEIGHT, false
 	}
 
-	go func() {
-		wg.Wait()
-		close(resultChan)
-	}()
+	g.mu.RLock()
+	defer g.mu.RUnlock()
 
-	neighbors := make([]T, 0)
-	for v := range resultChan {
-		neighbors = append(neighbors, v)
+	if !g.containsVertex(v) {
+		return DEFAULT_VERTEX_WEIGHT, false
 	}
 
-	return neighbors
+	return g.vertexWeight(*v), true
+}
+
+func (g *graph[T]) vertexWeight(v T) float64 {
+	if weight, ok := g.vertexWeights[v]; ok {
+		return weight
+	}
+	return DEFAULT_VERTEX_WEIGHT
 }
 
 func (g *graph[T]) GetWeight(from, to *T) (float64, bool) {
@@ -392,7 +489,10 @@ func (g *graph[T]) GetWeight(from, to *T) (float64, bool) {
 		return NO_WEIGHT, false
 	}
 
-	if !g.ContainsEdge(from, to) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if !g.containsEdge(from, to) {
 		return NO_WEIGHT, false
 	}
 
@@ -406,13 +506,55 @@ func (g *graph[T]) GetWeight(from, to *T) (float64, bool) {
 	return g.cache.WeightMatrix.Get(fromIdx, toIdx), true
 }
 
+func (g *graph[T]) InducedSubgraph(vertices []T) Graph[T] {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	sub := NewGraph[T](g.gtype, g.backend).(*graph[T])
+
+	selected := make(map[T]bool, len(vertices))
+	for _, v := range vertices {
+		if g.containsVertex(&v) {
+			selected[v] = true
+			sub.addVertex(&v)
+			if weight, ok := g.vertexWeights[v]; ok {
+				sub.setVertexWeight(v, weight)
+			}
+		}
+	}
+
+	for _, from := range sub.indexToVertex {
+		for _, to := range g.adjList[from] {
+			if !selected[to] || slices.Contains(sub.adjList[from], to) {
+				continue
+			}
+
+			sub.adjList[from] = append(sub.adjList[from], to)
+			if g.gtype == Undirected && from != to {
+				sub.adjList[to] = append(sub.adjList[to], from)
+			}
+
+			if g.cache.WeightMatrix != nil {
+				weight := g.cache.WeightMatrix.Get(g.vertexToIndex[from], g.vertexToIndex[to])
+				if weight != NO_WEIGHT {
+					sub.SetWeight(&from, &to, weight)
+				}
+			}
+		}
+	}
+
+	return sub
+}
+
 func (g *graph[T]) RemoveEdge(from, to *T) bool {
 	if from == nil || to == nil {
 		return false
 	}
 
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
 	var removed bool
-	var wg sync.WaitGroup
 
 	if neighbors, exists := g.adjList[*from]; exists {
 		for i, neighbor := range neighbors {
@@ -424,88 +566,62 @@ func (g *graph[T]) RemoveEdge(from, to *T) bool {
 		}
 	}
 
-	if g.gtype == Undirected {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			if neighbors, exists := g.adjList[*to]; exists {
-				for i, neighbor := range neighbors {
-					if neighbor == *from {
-						g.adjList[*to] = slices.Delete(neighbors, i, i+1)
-						break
-					}
+	if g.gtype == Undirected && *from != *to {
+		if neighbors, exists := g.adjList[*to]; exists {
+			for i, neighbor := range neighbors {
+				if neighbor == *from {
+					g.adjList[*to] = slices.Delete(neighbors, i, i+1)
+					break
 				}
 			}
-		}()
+		}
 	}
 
-	if g.cache.AdjMatrix != nil {
-		fromIdx := g.vertexToIndex[*from]
-		toIdx := g.vertexToIndex[*to]
-
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			g.cache.AdjMatrix.Set(fromIdx, toIdx, false)
-		}()
-
-		if g.gtype == Undirected {
-			wg.Add(1)
-			go func() {
-				defer wg.Done()
-				g.cache.AdjMatrix.Set(toIdx, fromIdx, false)
-			}()
-		}
+	if !removed {
+		return false
 	}
 
+	g.snap.Store(nil)
+	g.hasLoop = nil
+	g.isAcyclic = nil
+
 	if g.cache.WeightMatrix != nil {
 		fromIdx := g.vertexToIndex[*from]
 		toIdx := g.vertexToIndex[*to]
 
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			g.cache.WeightMatrix.Set(fromIdx, toIdx, NO_WEIGHT)
-		}()
-
+		g.cache.WeightMatrix.Set(fromIdx, toIdx, NO_WEIGHT)
 		if g.gtype == Undirected {
-			wg.Add(1)
-			go func() {
-				defer wg.Done()
-				g.cache.WeightMatrix.Set(toIdx, fromIdx, NO_WEIGHT)
-			}()
+			g.cache.WeightMatrix.Set(toIdx, fromIdx, NO_WEIGHT)
 		}
 	}
 
-	wg.Wait()
 	return removed
 }
 
 func (g *graph[T]) RemoveVertex(v *T) bool {
-	if v == nil || !g.ContainsVertex(v) {
+	if v == nil {
+		return false
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if !g.containsVertex(v) {
 		return false
 	}
 
 	index := g.vertexToIndex[*v]
 
 	delete(g.adjList, *v)
+	delete(g.vertexWeights, *v)
 
 	delete(g.vertexToIndex, *v)
 	g.indexToVertex = slices.Delete(g.indexToVertex, index, index+1)
 	g.size--
 
-	var mu sync.Mutex
-	var wg sync.WaitGroup
-	for i, vertex := range g.indexToVertex {
-		wg.Add(1)
-		go func(i int, vertex T) {
-			defer wg.Done()
-			mu.Lock()
-			g.vertexToIndex[vertex] = i
-			mu.Unlock()
-		}(i, vertex)
+	for i := index; i < len(g.indexToVertex); i++ {
+		g.vertexToIndex[g.indexToVertex[i]] = i
 	}
-	wg.Wait()
 
 	for from := range g.adjList {
 		newNeighbors := make([]T, 0, len(g.adjList[from]))
@@ -517,11 +633,9 @@ func (g *graph[T]) RemoveVertex(v *T) bool {
 		g.adjList[from] = newNeighbors
 	}
 
-	if g.cache.AdjMatrix != nil {
-		newAdjMatrix := newAdjMatrix(g.size)
-		reduceMatrix(g.cache.AdjMatrix, newAdjMatrix, index)
-		g.cache.AdjMatrix = newAdjMatrix
-	}
+	g.snap.Store(nil)
+	g.hasLoop = nil
+	g.isAcyclic = nil
 
 	if g.cache.WeightMatrix != nil {
 		newWeightMatrix := newWeightMatrix(g.size)
@@ -533,26 +647,58 @@ func (g *graph[T]) RemoveVertex(v *T) bool {
 }
 
 func (g *graph[T]) Reset() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
 	g.size = 0
 	g.adjList = make(map[T][]T)
+	g.vertexWeights = nil
 	g.cache = graphCache{}
+	g.snap.Store(nil)
 	g.hasLoop = nil
 	g.isAcyclic = nil
 	g.indexToVertex = make([]T, 0)
 	g.vertexToIndex = make(map[T]int)
 }
 
+func (g *graph[T]) SetVertexWeight(v *T, weight float64) bool {
+	if v == nil {
+		return false
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if !g.containsVertex(v) {
+		return false
+	}
+
+	g.setVertexWeight(*v, weight)
+	return true
+}
+
+func (g *graph[T]) setVertexWeight(v T, weight float64) {
+	if g.vertexWeights == nil {
+		g.vertexWeights = make(map[T]float64)
+	}
+	g.vertexWeights[v] = weight
+	g.snap.Store(nil)
+}
+
 func (g *graph[T]) SetWeight(from, to *T, weight float64) bool {
 	if from == nil || to == nil {
 		return false
 	}
 
-	if !g.ContainsEdge(from, to) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if !g.containsEdge(from, to) {
 		return false
 	}
 
 	if g.cache.WeightMatrix == nil {
-		initWeightMatrix(g)
+		g.cache.WeightMatrix = initWeightMatrix(g)
 	}
 
 	fromIdx := g.vertexToIndex[*from]
@@ -573,6 +719,9 @@ func (g *graph[T]) Dot(verticesToColor ...[]T) string {
 		solution = verticesToColor[0]
 	}
 
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
 	var buf bytes.Buffer
 
 	if g.gtype == Directed {
@@ -583,11 +732,16 @@ func (g *graph[T]) Dot(verticesToColor ...[]T) string {
 
 	buf.WriteString("layout=circo;\n")
 
-	for _, v := range g.GetAllVertices() {
+	for _, v := range g.indexToVertex {
+		weightStr := ""
+		if weight, ok := g.vertexWeights[v]; ok {
+			weightStr = fmt.Sprintf(", weight=%v", weight)
+		}
+
 		if slices.Contains(solution, v) {
-			buf.WriteString(fmt.Sprintf("  %v [color=red, style=filled, shape=circle];\n", v))
+			buf.WriteString(fmt.Sprintf("  %v [color=red, style=filled, shape=circle%s];\n", v, weightStr))
 		} else {
-			buf.WriteString(fmt.Sprintf("  %v [shape=circle];\n", v))
+			buf.WriteString(fmt.Sprintf("  %v [shape=circle%s];\n", v, weightStr))
 		}
 	}
 
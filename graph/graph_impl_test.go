@@ -0,0 +1,99 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"testing"
+)
+
+func randomGraph(gt GraphType, n int, p float64, seed int64, backend ...GraphBackend) Graph[string] {
+	rng := rand.New(rand.NewSource(seed))
+	g := NewGraph[string](gt, backend...)
+	vertices := make([]string, n)
+	for i := range vertices {
+		vertices[i] = fmt.Sprint("v", i)
+		g.AddVertex(&vertices[i])
+	}
+	for i := range vertices {
+		for j := i + 1; j < n; j++ {
+			if rng.Float64() < p {
+				g.AddEdge(&vertices[i], &vertices[j])
+			}
+		}
+	}
+	return g
+}
+
+func TestGraphConcurrentReadersAndWriter(t *testing.T) {
+	for name, backend := range map[string]GraphBackend{"dense": DenseBackend, "sparse": SparseBackend} {
+		t.Run(name, func(t *testing.T) {
+			g := randomGraph(Undirected, 60, 0.2, 1, backend)
+			solver, err := DefaultRegistry[string]().New(BranchAndBoundSolver, Params{BudgetParam: 200})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var wg sync.WaitGroup
+			stop := make(chan struct{})
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer close(stop)
+
+				rng := rand.New(rand.NewSource(2))
+				for i := range 300 {
+					from := fmt.Sprint("v", rng.Intn(80))
+					to := fmt.Sprint("v", rng.Intn(80))
+					extra := fmt.Sprint("w", i)
+					g.AddEdge(&from, &to)
+					g.AddVertex(&extra)
+					if i%3 == 0 {
+						g.RemoveVertex(&extra)
+					}
+					if i%10 == 0 {
+						g.RemoveVertex(&from)
+					}
+				}
+			}()
+
+			readers := []func(){
+				func() {
+					for _, v := range g.GetAllVertices() {
+						g.GetNeighbors(&v)
+					}
+				},
+				func() {
+					ComputeAlphaBounds(g)
+				},
+				func() {
+					ctx, cancel := WithLimits(context.Background(), solver.Limits())
+					defer cancel()
+					solver.Solve(ctx, g)
+				},
+				func() {
+					MISGreedy(context.Background(), g, WithSeed(1))
+				},
+			}
+
+			for _, read := range readers {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for {
+						select {
+						case <-stop:
+							return
+						default:
+							read()
+						}
+					}
+				}()
+			}
+
+			wg.Wait()
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package graph
+
+import "sync"
+
+type snapshot[T comparable] struct {
+	gtype         GraphType
+	dense         bool
+	indexToVertex []T
+	vertexToIndex map[T]int
+	weights       []float64
+	csr           *csrMatrix
+
+	matrixOnce sync.Once
+	matrix     *adjMatrix
+}
+
+func (s *snapshot[T]) Size() int {
+	return len(s.indexToVertex)
+}
+
+func (s *snapshot[T]) CSR() *csrMatrix {
+	return s.csr
+}
+
+func (s *snapshot[T]) Matrix() *adjMatrix {
+	s.matrixOnce.Do(func() {
+		s.matrix = initAdjMatrix(s.csr)
+	})
+	return s.matrix
+}
+
+func (s *snapshot[T]) Weight(i int) float64 {
+	return s.weights[i]
+}
+
+func (s *snapshot[T]) Vertices(indices []int) []T {
+	result := make([]T, len(indices))
+	for i, idx := range indices {
+		result[i] = s.indexToVertex[idx]
+	}
+	return result
+}
+
+func snapshotOf[T comparable](g Graph[T]) *snapshot[T] {
+	graph, ok := g.(*graph[T])
+	if !ok || graph == nil {
+		return nil
+	}
+	return graph.snapshot()
+}
+
+func (g *graph[T]) snapshot() *snapshot[T] {
+	if s := g.snap.Load(); s != nil {
+		return s
+	}
+
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if s := g.snap.Load(); s != nil {
+		return s
+	}
+
+	s := &snapshot[T]{
+		gtype:         g.gtype,
+		dense:         useDenseBackend(g),
+		indexToVertex: g.getAllVertices(),
+		vertexToIndex: make(map[T]int, len(g.vertexToIndex)),
+		csr:           initCSR(g),
+	}
+	for v, idx := range g.vertexToIndex {
+		s.vertexToIndex[v] = idx
+	}
+
+	s.weights = make([]float64, len(s.indexToVertex))
+	for i, v := range s.indexToVertex {
+		s.weights[i] = g.vertexWeight(v)
+	}
+
+	g.snap.Store(s)
+	return s
+}
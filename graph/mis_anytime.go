@@ -0,0 +1,110 @@
+package graph
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+type Improvement[T comparable] struct {
+	Vertices []T
+	Size     int
+	Weight   float64
+	Elapsed  time.Duration
+	Time     time.Time
+}
+
+type improvementReporter[T comparable] struct {
+	mu       sync.Mutex
+	fn       func(Improvement[T])
+	s        *snapshot[T]
+	weighted bool
+	start    time.Time
+	best     float64
+}
+
+func newImprovementReporter[T comparable](o misOptions, s *snapshot[T]) *improvementReporter[T] {
+	fn, _ := o.onImprove.(func(Improvement[T]))
+	if fn == nil || s == nil {
+		return nil
+	}
+	return &improvementReporter[T]{
+		fn:       fn,
+		s:        s,
+		weighted: o.weighted,
+		start:    time.Now(),
+		best:     -1,
+	}
+}
+
+func (r *improvementReporter[T]) report(vertices []T) {
+	if r == nil {
+		return
+	}
+
+	weight := 0.0
+	for _, v := range vertices {
+		if idx, ok := r.s.vertexToIndex[v]; ok {
+			weight += r.s.Weight(idx)
+		}
+	}
+
+	objective := float64(len(vertices))
+	if r.weighted {
+		objective = weight
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if objective <= r.best {
+		return
+	}
+	r.best = objective
+
+	now := time.Now()
+	r.fn(Improvement[T]{
+		Vertices: slices.Clone(vertices),
+		Size:     len(vertices),
+		Weight:   weight,
+		Elapsed:  now.Sub(r.start),
+		Time:     now,
+	})
+}
+
+func (r *improvementReporter[T]) reportIndices(indices []int) {
+	if r == nil {
+		return
+	}
+	r.report(r.s.Vertices(indices))
+}
+
+func (r *improvementReporter[T]) reportGenome(genome []bool) {
+	if r == nil {
+		return
+	}
+
+	var indices []int
+	for i, inc := range genome {
+		if inc {
+			indices = append(indices, i)
+		}
+	}
+	r.reportIndices(indices)
+}
+
+func fallbackIndices[T comparable](s *snapshot[T], o misOptions) []int {
+	if o.weighted {
+		return weightedGreedyIndices(context.Background(), s)
+	}
+	return greedyIndices(context.Background(), s, o.rng())
+}
+
+func fallbackSolution[T comparable](g Graph[T], o misOptions) []T {
+	s := snapshotOf(g)
+	if s == nil {
+		return nil
+	}
+	return s.Vertices(fallbackIndices(s, o))
+}
@@ -0,0 +1,55 @@
+package graph
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestImprovementChannelCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	g := randomGraph(Undirected, 80, 0.1, 1)
+	ch := make(chan Improvement[string])
+	done := make(chan []string)
+	go func() {
+		cfg := GreedyConfig{Rule: GRASP, RCLAlpha: 0.3, Restarts: 20, LocalIters: 20}
+		done <- MISGreedyVariant(context.Background(), g, cfg, WithSeed(1), WithComponentDecomposition(4), WithImprovementChannel(ctx, ch))
+	}()
+
+	select {
+	case result := <-done:
+		if len(result) == 0 {
+			t.Fatal("пустое решение")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("решатель заблокирован отправкой в канал улучшений")
+	}
+}
+
+func TestImprovementChannel(t *testing.T) {
+	g := randomGraph(Undirected, 60, 0.1, 2)
+	ch := make(chan Improvement[string])
+	done := make(chan []string)
+	go func() {
+		cfg := GreedyConfig{Rule: GRASP, RCLAlpha: 0.3, Restarts: 20, LocalIters: 20}
+		done <- MISGreedyVariant(context.Background(), g, cfg, WithSeed(1), WithImprovementChannel(context.Background(), ch))
+	}()
+
+	best := 0
+	for {
+		select {
+		case imp := <-ch:
+			if imp.Size <= best {
+				t.Fatalf("улучшение размера %d после %d", imp.Size, best)
+			}
+			best = imp.Size
+		case result := <-done:
+			if best != len(result) {
+				t.Fatalf("последнее улучшение %d, решение %d", best, len(result))
+			}
+			return
+		}
+	}
+}
@@ -0,0 +1,216 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+	"strings"
+)
+
+type Bound struct {
+	Name  string
+	Value int
+}
+
+type AlphaBounds struct {
+	Lower []Bound
+	Upper []Bound
+}
+
+func (b AlphaBounds) BestLower() Bound {
+	best := Bound{Value: math.MinInt}
+	for _, bound := range b.Lower {
+		if bound.Value > best.Value {
+			best = bound
+		}
+	}
+	return best
+}
+
+func (b AlphaBounds) BestUpper() Bound {
+	best := Bound{Value: math.MaxInt}
+	for _, bound := range b.Upper {
+		if bound.Value < best.Value {
+			best = bound
+		}
+	}
+	return best
+}
+
+func (b AlphaBounds) String() string {
+	var parts []string
+	for _, bound := range b.Lower {
+		parts = append(parts, fmt.Sprintf("%s ≥ %d", bound.Name, bound.Value))
+	}
+	for _, bound := range b.Upper {
+		parts = append(parts, fmt.Sprintf("%s ≤ %d", bound.Name, bound.Value))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func ComputeAlphaBounds[T comparable](g Graph[T]) AlphaBounds {
+	s := snapshotOf(g)
+	if s == nil {
+		return AlphaBounds{}
+	}
+
+	adj := loopFreeAdjacency(s.CSR())
+	n := len(adj)
+	if n == 0 {
+		return AlphaBounds{
+			Lower: []Bound{{Name: "Жадная", Value: 0}},
+			Upper: []Bound{{Name: "Число вершин", Value: 0}},
+		}
+	}
+
+	edges, maxDegree := 0, 0
+	caroWei := 0.0
+	for _, row := range adj {
+		edges += len(row)
+		maxDegree = max(maxDegree, len(row))
+		caroWei += 1 / float64(len(row)+1)
+	}
+	edges /= 2
+	averageDegree := 2 * float64(edges) / float64(n)
+
+	greedy := len(greedyIndices(context.Background(), s, rand.New(rand.NewSource(0))))
+
+	degreeBound := n
+	if maxDegree > 0 {
+		degreeBound = n - (edges+maxDegree-1)/maxDegree
+	}
+
+	return AlphaBounds{
+		Lower: []Bound{
+			{Name: "Жадная", Value: greedy},
+			{Name: "Каро–Вей", Value: int(math.Ceil(caroWei - 1e-9))},
+			{Name: "Туран", Value: int(math.Ceil(float64(n)/(averageDegree+1) - 1e-9))},
+		},
+		Upper: []Bound{
+			{Name: "Покрытие кликами", Value: greedyCliqueCover(adj)},
+			{Name: "LP-релаксация", Value: n - (doubleCoverMatching(adj)+1)/2},
+			{Name: "Степенная", Value: degreeBound},
+		},
+	}
+}
+
+func loopFreeAdjacency(csr *csrMatrix) [][]int {
+	index := make([]int, csr.Size())
+	n := 0
+	for i := range csr.Size() {
+		if csr.Get(i, i) {
+			index[i] = -1
+			continue
+		}
+		index[i] = n
+		n++
+	}
+
+	adj := make([][]int, n)
+	for i := range csr.Size() {
+		if index[i] < 0 {
+			continue
+		}
+		for _, j := range csr.Row(i) {
+			if index[j] >= 0 {
+				adj[index[i]] = append(adj[index[i]], index[j])
+			}
+		}
+	}
+	return adj
+}
+
+func greedyCliqueCover(adj [][]int) int {
+	order := make([]int, len(adj))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int { return len(adj[b]) - len(adj[a]) })
+
+	covered := make([]bool, len(adj))
+	cliques := 0
+	for _, v := range order {
+		if covered[v] {
+			continue
+		}
+		cliques++
+		covered[v] = true
+
+		clique := []int{v}
+		candidates := slices.Clone(adj[v])
+		slices.SortStableFunc(candidates, func(a, b int) int { return len(adj[b]) - len(adj[a]) })
+		for _, u := range candidates {
+			if covered[u] {
+				continue
+			}
+			if !slices.ContainsFunc(clique, func(w int) bool { _, found := slices.BinarySearch(adj[u], w); return !found }) {
+				clique = append(clique, u)
+				covered[u] = true
+			}
+		}
+	}
+	return cliques
+}
+
+func doubleCoverMatching(adj [][]int) int {
+	n := len(adj)
+	matchLeft := make([]int, n)
+	matchRight := make([]int, n)
+	for i := range n {
+		matchLeft[i], matchRight[i] = -1, -1
+	}
+	dist := make([]int, n)
+
+	bfs := func() bool {
+		var queue []int
+		found := false
+		for v := range n {
+			if matchLeft[v] < 0 {
+				dist[v] = 0
+				queue = append(queue, v)
+			} else {
+				dist[v] = -1
+			}
+		}
+		for len(queue) > 0 {
+			v := queue[0]
+			queue = queue[1:]
+			for _, u := range adj[v] {
+				w := matchRight[u]
+				if w < 0 {
+					found = true
+				} else if dist[w] < 0 {
+					dist[w] = dist[v] + 1
+					queue = append(queue, w)
+				}
+			}
+		}
+		return found
+	}
+
+	var dfs func(v int) bool
+	dfs = func(v int) bool {
+		for _, u := range adj[v] {
+			w := matchRight[u]
+			if w < 0 || (dist[w] == dist[v]+1 && dfs(w)) {
+				matchLeft[v] = u
+				matchRight[u] = v
+				return true
+			}
+		}
+		dist[v] = -1
+		return false
+	}
+
+	matching := 0
+	for bfs() {
+		for v := range n {
+			if matchLeft[v] < 0 && dfs(v) {
+				matching++
+			}
+		}
+	}
+	return matching
+}
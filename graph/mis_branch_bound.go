@@ -0,0 +1,190 @@
+package graph
+
+import (
+	"context"
+	"slices"
+)
+
+func MISBranchAndBound[T comparable](ctx context.Context, g Graph[T], opts ...MISOption) []T {
+	o := applyMISOptions(opts)
+
+	if o.components {
+		return solveByComponents(ctx, g, opts, func(component Graph[T], opts ...MISOption) []T {
+			return MISBranchAndBound(ctx, component, opts...)
+		})
+	}
+
+	if o.kernelize {
+		return solveOnKernel(ctx, g, opts, func(kernel Graph[T], opts ...MISOption) []T {
+			return MISBranchAndBound(ctx, kernel, opts...)
+		})
+	}
+
+	s := snapshotOf(g)
+	if s == nil {
+		return nil
+	}
+
+	n := s.Size()
+	if n == 0 {
+		return nil
+	}
+
+	order, neighbors := orderForBranchAndBound(s.Matrix())
+	reporter := newImprovementReporter(o, s)
+
+	bb := &branchAndBound{
+		ctx:       ctx,
+		budget:    budgetOf(ctx),
+		n:         n,
+		neighbors: neighbors,
+		current:   make([]int, 0, n),
+	}
+	bb.onImprove = reportInOrder(reporter, order)
+
+	candidates := newBitset(n)
+	for i := range n {
+		if !neighbors.Get(i, i) {
+			candidates.Set(i)
+		}
+	}
+
+	bb.best = greedyIndependentSet(neighbors, candidates)
+	bb.improved()
+	bb.expand(candidates)
+
+	result := make([]T, len(bb.best))
+	for i, idx := range bb.best {
+		result[i] = s.indexToVertex[order[idx]]
+	}
+	return result
+}
+
+type branchAndBound struct {
+	ctx       context.Context
+	budget    *budget
+	n         int
+	neighbors *adjMatrix
+	current   []int
+	best      []int
+	onImprove func(best []int)
+}
+
+func (bb *branchAndBound) improved() {
+	if bb.onImprove != nil {
+		bb.onImprove(bb.best)
+	}
+}
+
+func (bb *branchAndBound) expand(candidates bitset) {
+	bb.budget.spend()
+	if bb.ctx.Err() != nil {
+		return
+	}
+
+	order, bounds := cliqueCoverBounds(bb.neighbors, candidates)
+
+	for k := len(order) - 1; k >= 0; k-- {
+		if len(bb.current)+bounds[k] <= len(bb.best) {
+			return
+		}
+
+		v := order[k]
+		bb.current = append(bb.current, v)
+
+		next := candidates.Clone()
+		next.Clear(v)
+		next.AndNot(bb.neighbors.Row(v))
+
+		if next.IsEmpty() {
+			if len(bb.current) > len(bb.best) {
+				bb.best = slices.Clone(bb.current)
+				bb.improved()
+			}
+		} else {
+			bb.expand(next)
+		}
+
+		bb.current = bb.current[:len(bb.current)-1]
+		candidates.Clear(v)
+
+		if bb.ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+func cliqueCoverBounds(neighbors *adjMatrix, candidates bitset) ([]int, []int) {
+	uncovered := candidates.Clone()
+	order := make([]int, 0, candidates.Count())
+	bounds := make([]int, 0, cap(order))
+
+	cliques := 0
+	for !uncovered.IsEmpty() {
+		cliques++
+		clique := uncovered.Clone()
+		for !clique.IsEmpty() {
+			v := clique.First()
+			uncovered.Clear(v)
+			clique.Clear(v)
+			clique.And(neighbors.Row(v))
+
+			order = append(order, v)
+			bounds = append(bounds, cliques)
+		}
+	}
+
+	return order, bounds
+}
+
+func greedyIndependentSet(neighbors *adjMatrix, candidates bitset) []int {
+	available := candidates.Clone()
+	var result []int
+	for !available.IsEmpty() {
+		i := available.First()
+		result = append(result, i)
+		available.Clear(i)
+		available.AndNot(neighbors.Row(i))
+	}
+	return result
+}
+
+func reportInOrder[T comparable](reporter *improvementReporter[T], order []int) func(best []int) {
+	if reporter == nil {
+		return nil
+	}
+	return func(best []int) {
+		indices := make([]int, len(best))
+		for i, idx := range best {
+			indices[i] = order[idx]
+		}
+		reporter.reportIndices(indices)
+	}
+}
+
+func orderForBranchAndBound(adj *adjMatrix) ([]int, *adjMatrix) {
+	n := adj.Size()
+
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		return adj.Degree(a) - adj.Degree(b)
+	})
+
+	position := make([]int, n)
+	for i, v := range order {
+		position[v] = i
+	}
+
+	neighbors := newAdjMatrix(n)
+	for i, u := range order {
+		row := neighbors.Row(i)
+		adj.Row(u).ForEach(n, func(v int) {
+			row.Set(position[v])
+		})
+	}
+
+	return order, neighbors
+}
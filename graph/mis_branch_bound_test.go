@@ -0,0 +1,56 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+func completeEdges(n int) [][2]int {
+	var edges [][2]int
+	for i := range n {
+		for j := i + 1; j < n; j++ {
+			edges = append(edges, [2]int{i, j})
+		}
+	}
+	return edges
+}
+
+func TestBranchAndBound(t *testing.T) {
+	tests := []struct {
+		name string
+		g    Graph[string]
+	}{
+		{"пустой граф", buildGraph(0, nil)},
+		{"одна вершина", buildGraph(1, nil)},
+		{"без рёбер", buildGraph(6, nil)},
+		{"полный граф K1", buildGraph(1, completeEdges(1))},
+		{"полный граф K7", buildGraph(7, completeEdges(7))},
+		{"полный граф K16", buildGraph(16, completeEdges(16))},
+	}
+	for seed := range int64(60) {
+		n := 1 + int(seed)%16
+		p := 0.1 + 0.1*float64(seed%8)
+		backend := DenseBackend
+		if seed%2 == 1 {
+			backend = SparseBackend
+		}
+		tests = append(tests, struct {
+			name string
+			g    Graph[string]
+		}{fmt.Sprintf("случайный граф n=%d p=%.1f", n, p), randomGraph(Undirected, n, p, seed, backend)})
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			alpha := bruteForceAlpha(tt.g)
+			result := MISBranchAndBound(context.Background(), tt.g)
+			if len(result) != alpha {
+				t.Fatalf("найдено %d вершин, α = %d", len(result), alpha)
+			}
+			if check := VerifyIndependentSet(tt.g, result); !check.Valid() {
+				t.Fatal(check)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package graph
+
+import (
+	"context"
+	"runtime"
+	"slices"
+	"sync"
+)
+
+type MISSolver[T comparable] func(ctx context.Context, g Graph[T]) []T
+
+func MISByComponents[T comparable](ctx context.Context, g Graph[T], solve MISSolver[T], workers int) []T {
+	return byComponents(ctx, g, solve, workers, misOptions{})
+}
+
+func byComponents[T comparable](ctx context.Context, g Graph[T], solve MISSolver[T], workers int, o misOptions) []T {
+	components := ConnectedComponents(g)
+	if components == nil {
+		return nil
+	}
+
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	workers = min(workers, max(len(components), 1))
+
+	jobs := make(chan int)
+	results := make([][]T, len(components))
+
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if ctx.Err() != nil {
+					continue
+				}
+				results[i] = solve(ctx, g.InducedSubgraph(components[i]))
+			}
+		}()
+	}
+
+	for i := range components {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	solution := make([]T, 0, len(components))
+	for i, part := range results {
+		if part == nil {
+			if ctx.Err() == nil {
+				return nil
+			}
+			part = fallbackSolution(g.InducedSubgraph(components[i]), o)
+		}
+		solution = append(solution, part...)
+	}
+	return slices.Clip(solution)
+}
+
+func solveByComponents[T comparable](ctx context.Context, g Graph[T], opts []MISOption, solve func(component Graph[T], opts ...MISOption) []T) []T {
+	o := applyMISOptions(opts)
+	componentOpts := slices.Concat(opts, []MISOption{withoutComponentDecomposition(), withImprovementHook(nil)})
+
+	reporter := newImprovementReporter(o, snapshotOf(g))
+	var mu sync.Mutex
+	bests := make(map[Graph[T]][]T)
+
+	solution := byComponents(ctx, g, func(ctx context.Context, component Graph[T]) []T {
+		if reporter == nil {
+			return solve(component, componentOpts...)
+		}
+
+		return solve(component, append(componentOpts[:len(componentOpts):len(componentOpts)], WithImprovements(func(imp Improvement[T]) {
+			mu.Lock()
+			bests[component] = imp.Vertices
+			var union []T
+			for _, part := range bests {
+				union = append(union, part...)
+			}
+			mu.Unlock()
+
+			reporter.report(union)
+		}))...)
+	}, o.componentWorkers, o)
+
+	reporter.report(solution)
+	return solution
+}
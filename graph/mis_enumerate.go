@@ -0,0 +1,148 @@
+package graph
+
+import (
+	"context"
+	"slices"
+)
+
+type MaximumSets[T comparable] struct {
+	Alpha     int
+	Sets      [][]T
+	Complete  bool
+	Frequency map[T]float64
+}
+
+func EnumerateMaximumIndependentSets[T comparable](ctx context.Context, g Graph[T], limit int, opts ...MISOption) MaximumSets[T] {
+	o := applyMISOptions(opts)
+
+	s := snapshotOf(g)
+	if s == nil {
+		return MaximumSets[T]{}
+	}
+
+	n := s.Size()
+	order, neighbors := orderForBranchAndBound(s.Matrix())
+
+	e := &enumerator{
+		ctx:       ctx,
+		budget:    budgetOf(ctx),
+		limit:     limit,
+		neighbors: neighbors,
+		current:   make([]int, 0, n),
+		onImprove: reportInOrder(newImprovementReporter(o, s), order),
+	}
+
+	candidates := newBitset(n)
+	for i := range n {
+		if !neighbors.Get(i, i) {
+			candidates.Set(i)
+		}
+	}
+
+	greedy := greedyIndependentSet(neighbors, candidates)
+	e.size = len(greedy)
+	if e.onImprove != nil {
+		e.onImprove(greedy)
+	}
+	e.expand(candidates)
+
+	complete := ctx.Err() == nil && !e.truncated
+	if len(e.sets) == 0 {
+		e.sets = [][]int{greedy}
+		complete = false
+	}
+
+	result := MaximumSets[T]{
+		Alpha:     e.size,
+		Sets:      make([][]T, len(e.sets)),
+		Complete:  complete,
+		Frequency: make(map[T]float64, n),
+	}
+
+	counts := make([]int, n)
+	for i, set := range e.sets {
+		result.Sets[i] = make([]T, len(set))
+		for j, idx := range set {
+			result.Sets[i][j] = s.indexToVertex[order[idx]]
+			counts[idx]++
+		}
+	}
+	for idx, count := range counts {
+		result.Frequency[s.indexToVertex[order[idx]]] = float64(count) / float64(len(e.sets))
+	}
+	return result
+}
+
+type enumerator struct {
+	ctx       context.Context
+	budget    *budget
+	limit     int
+	neighbors *adjMatrix
+	current   []int
+	size      int
+	sets      [][]int
+	truncated bool
+	onImprove func(best []int)
+}
+
+func (e *enumerator) full() bool {
+	return e.limit > 0 && len(e.sets) >= e.limit
+}
+
+func (e *enumerator) promising(bound int) bool {
+	if e.truncated {
+		return len(e.current)+bound > e.size
+	}
+	return len(e.current)+bound >= e.size
+}
+
+func (e *enumerator) record() {
+	switch {
+	case len(e.current) > e.size || len(e.sets) == 0 && len(e.current) == e.size:
+		e.size = len(e.current)
+		e.sets = [][]int{slices.Clone(e.current)}
+		e.truncated = false
+		if e.onImprove != nil {
+			e.onImprove(e.current)
+		}
+	case len(e.current) == e.size && e.full():
+		e.truncated = true
+	case len(e.current) == e.size:
+		e.sets = append(e.sets, slices.Clone(e.current))
+	}
+}
+
+func (e *enumerator) expand(candidates bitset) {
+	e.budget.spend()
+	if e.ctx.Err() != nil {
+		return
+	}
+
+	if candidates.IsEmpty() {
+		e.record()
+		return
+	}
+
+	order, bounds := cliqueCoverBounds(e.neighbors, candidates)
+
+	for k := len(order) - 1; k >= 0; k-- {
+		if !e.promising(bounds[k]) {
+			return
+		}
+
+		v := order[k]
+		e.current = append(e.current, v)
+
+		next := candidates.Clone()
+		next.Clear(v)
+		next.AndNot(e.neighbors.Row(v))
+		e.expand(next)
+
+		e.current = e.current[:len(e.current)-1]
+		candidates.Clear(v)
+
+		if e.ctx.Err() != nil {
+			return
+		}
+	}
+}
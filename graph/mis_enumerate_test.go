@@ -0,0 +1,113 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func setKeys(sets [][]string) []string {
+	keys := make([]string, len(sets))
+	for i, set := range sets {
+		sorted := slices.Sorted(slices.Values(set))
+		keys[i] = strings.Join(sorted, ",")
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+func TestEnumerateMaximumIndependentSets(t *testing.T) {
+	tests := []struct {
+		name string
+		g    Graph[string]
+	}{
+		{"без рёбер", buildGraph(5, nil)},
+		{"цикл C6", buildGraph(6, cycleEdges(6))},
+		{"полный граф K5", buildGraph(5, completeEdges(5))},
+		{"петля", buildGraph(4, [][2]int{{0, 1}, {2, 2}, {2, 3}})},
+	}
+	for seed := range int64(40) {
+		n := 4 + int(seed)%12
+		p := []float64{0.1, 0.3, 0.5, 0.8}[seed%4]
+		tests = append(tests, struct {
+			name string
+			g    Graph[string]
+		}{fmt.Sprintf("случайный граф n=%d p=%.1f", n, p), randomGraph(Undirected, n, p, seed)})
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := bruteForceOptima(tt.g)
+			alpha := len(want[0])
+
+			got := EnumerateMaximumIndependentSets(context.Background(), tt.g, 0)
+			if got.Alpha != alpha || !got.Complete {
+				t.Fatalf("α = %d, полное = %v, ожидалось α = %d", got.Alpha, got.Complete, alpha)
+			}
+			if !slices.Equal(setKeys(got.Sets), setKeys(want)) {
+				t.Fatalf("найдено %d оптимумов, ожидалось %d", len(got.Sets), len(want))
+			}
+			for _, set := range got.Sets {
+				if check := VerifyIndependentSet(tt.g, set); !check.Valid() {
+					t.Fatal(check)
+				}
+			}
+
+			for _, v := range tt.g.GetAllVertices() {
+				count := 0
+				for _, set := range want {
+					if slices.Contains(set, v) {
+						count++
+					}
+				}
+				if freq := float64(count) / float64(len(want)); math.Abs(got.Frequency[v]-freq) > 1e-9 {
+					t.Fatalf("частота %s = %g, ожидалось %g", v, got.Frequency[v], freq)
+				}
+			}
+
+			if len(want) < 2 {
+				return
+			}
+			limited := EnumerateMaximumIndependentSets(context.Background(), tt.g, len(want)-1)
+			if limited.Alpha != alpha || len(limited.Sets) != len(want)-1 || limited.Complete {
+				t.Fatalf("с ограничением %d: α = %d, найдено %d, полное = %v", len(want)-1, limited.Alpha, len(limited.Sets), limited.Complete)
+			}
+			exact := EnumerateMaximumIndependentSets(context.Background(), tt.g, len(want))
+			if len(exact.Sets) != len(want) || !exact.Complete {
+				t.Fatalf("с ограничением %d: найдено %d, полное = %v", len(want), len(exact.Sets), exact.Complete)
+			}
+		})
+	}
+}
+
+func TestEnumerateInterrupted(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	g := randomGraph(Undirected, 40, 0.2, 1)
+	got := EnumerateMaximumIndependentSets(ctx, g, 0)
+	if got.Complete || len(got.Sets) != 1 || got.Alpha != len(got.Sets[0]) {
+		t.Fatalf("α = %d, найдено %d, полное = %v", got.Alpha, len(got.Sets), got.Complete)
+	}
+	if check := VerifyIndependentSet(g, got.Sets[0]); !check.Valid() {
+		t.Fatal(check)
+	}
+
+	solver, err := DefaultRegistry[string]().New(BranchAndBoundSolver, Params{EnumerateParam: true, BudgetParam: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	limitCtx, stop := WithLimits(context.Background(), solver.Limits())
+	defer stop()
+	g = randomGraph(Undirected, 120, 0.1, 2)
+	solution, stats := solver.Solve(limitCtx, g)
+	if stats.Optimal || !stats.Interrupted || solution.Optima != nil {
+		t.Fatalf("оптимально = %v, прервано = %v, оптимумы = %v", stats.Optimal, stats.Interrupted, solution.Optima)
+	}
+	if check := VerifyIndependentSet(g, solution.Vertices); len(solution.Vertices) == 0 || !check.Valid() {
+		t.Fatalf("решение после прерывания из %d вершин: %s", len(solution.Vertices), check)
+	}
+}
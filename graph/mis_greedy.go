@@ -5,89 +5,152 @@ import (
 	"math/rand"
 )
 
-func MISGreedy[T comparable](ctx context.Context, g Graph[T]) []T {
-	graph, ok := g.(*graph[T])
-	if !ok {
+func MISGreedy[T comparable](ctx context.Context, g Graph[T], opts ...MISOption) []T {
+	s := snapshotOf(g)
+	if s == nil {
 		return nil
 	}
 
-	n := graph.size
-	deleted := make([]bool, n)
+	return s.Vertices(greedyIndices(ctx, s, applyMISOptions(opts).rng()))
+}
+
+func greedyIndices[T comparable](ctx context.Context, s *snapshot[T], rng *rand.Rand) []int {
+	if s.dense {
+		return greedyDense(ctx, s.Matrix(), rng)
+	}
+	return greedySparse(ctx, s.CSR(), rng)
+}
+
+func greedyDense(ctx context.Context, adj *adjMatrix, rng *rand.Rand) []int {
 	var solutionIndices []int
+	alive := gatherCandidates(adj)
+
+	for !alive.IsEmpty() {
+		if ctx.Err() != nil {
+			return solutionIndices
+		}
+
+		bestIdx := findMinDegree(adj, alive, rng)
+		solutionIndices = append(solutionIndices, bestIdx)
+		markDeleted(adj, bestIdx, alive)
+	}
 
-	initAdjMatrix(graph)
-	adjMatrix := graph.cache.AdjMatrix
+	return solutionIndices
+}
+
+func greedySparse(ctx context.Context, adj *csrMatrix, rng *rand.Rand) []int {
+	n := adj.Size()
+	alive := make([]bool, n)
+	degree := make([]int, n)
+	position := make([]int, n)
+	var buckets [][]int
+
+	for i := range n {
+		alive[i] = !adj.Get(i, i)
+	}
+
+	for i := range n {
+		if !alive[i] {
+			continue
+		}
+		for _, j := range adj.Row(i) {
+			if alive[j] {
+				degree[i]++
+			}
+		}
+		for len(buckets) <= degree[i] {
+			buckets = append(buckets, nil)
+		}
+		position[i] = len(buckets[degree[i]])
+		buckets[degree[i]] = append(buckets[degree[i]], i)
+	}
+
+	detach := func(v int) {
+		bucket := buckets[degree[v]]
+		last := bucket[len(bucket)-1]
+		bucket[position[v]] = last
+		position[last] = position[v]
+		buckets[degree[v]] = bucket[:len(bucket)-1]
+	}
+
+	var solutionIndices, removed []int
+	minDegree := 0
 
 	for {
 		if ctx.Err() != nil {
-			return nil
+			return solutionIndices
 		}
 
-		candidates := gatherCandidates(adjMatrix, deleted)
-		if len(candidates) == 0 {
+		for minDegree < len(buckets) && len(buckets[minDegree]) == 0 {
+			minDegree++
+		}
+		if minDegree == len(buckets) {
 			break
 		}
 
-		bestIdx := findMinDegree(adjMatrix, candidates, deleted)
-		if ctx.Err() != nil {
-			return nil
+		bucket := buckets[minDegree]
+		v := bucket[rng.Intn(len(bucket))]
+		solutionIndices = append(solutionIndices, v)
+
+		detach(v)
+		alive[v] = false
+		removed = removed[:0]
+		for _, u := range adj.Row(v) {
+			if alive[u] {
+				detach(u)
+				alive[u] = false
+				removed = append(removed, u)
+			}
 		}
 
-		solutionIndices = append(solutionIndices, bestIdx)
-		markDeleted(adjMatrix, bestIdx, deleted)
+		for _, u := range removed {
+			for _, w := range adj.Row(u) {
+				if !alive[w] {
+					continue
+				}
+				detach(w)
+				degree[w]--
+				position[w] = len(buckets[degree[w]])
+				buckets[degree[w]] = append(buckets[degree[w]], w)
+				minDegree = min(minDegree, degree[w])
+			}
+		}
 	}
 
-	result := make([]T, len(solutionIndices))
-	for i, idx := range solutionIndices {
-		result[i] = graph.indexToVertex[idx]
-	}
-	return result
+	return solutionIndices
 }
 
-func gatherCandidates(adj *adjMatrix, deleted []bool) []int {
-	var result []int
-	n := adj.Size()
-	for j := range n {
-		if !deleted[j] {
-			result = append(result, j)
+func gatherCandidates(adj *adjMatrix) bitset {
+	result := newBitset(adj.Size())
+	for j := range adj.Size() {
+		if !adj.Get(j, j) {
+			result.Set(j)
 		}
 	}
 	return result
 }
 
-func markDeleted(adj *adjMatrix, idx int, deleted []bool) {
-	deleted[idx] = true
-	for j := range adj.Size() {
-		if adj.Get(idx, j) || adj.Get(j, idx) {
-			deleted[j] = true
-		}
-	}
+func markDeleted(adj *adjMatrix, idx int, alive bitset) {
+	alive.Clear(idx)
+	alive.AndNot(adj.Row(idx))
 }
 
-func findMinDegree(adj *adjMatrix, candidates []int, deleted []bool) int {
+func findMinDegree(adj *adjMatrix, alive bitset, rng *rand.Rand) int {
 	bestDegree := adj.Size() + 1
 	var minCandidates []int
 
-	for _, idx := range candidates {
-		if deleted[idx] {
-			continue
-		}
-		degree := 0
-		for j := range adj.Size() {
-			if adj.Get(idx, j) && !deleted[j] {
-				degree++
-			}
-		}
+	alive.ForEach(adj.Size(), func(idx int) {
+		degree := adj.DegreeIn(idx, alive)
 		if degree < bestDegree {
 			bestDegree = degree
 			minCandidates = []int{idx}
 		} else if degree == bestDegree {
 			minCandidates = append(minCandidates, idx)
 		}
-	}
+	})
 
 	if len(minCandidates) > 0 {
-		return minCandidates[rand.Intn(len(minCandidates))]
+		return minCandidates[rng.Intn(len(minCandidates))]
 	}
 	return -1
 }
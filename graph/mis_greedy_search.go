@@ -2,38 +2,44 @@ package graph
 
 import "context"
 
-func MISGreedySearch[T comparable](ctx context.Context, g Graph[T], localIters int) []T {
-	graph, ok := g.(*graph[T])
-	if !ok {
-		return nil
+func MISGreedySearch[T comparable](ctx context.Context, g Graph[T], localIters int, opts ...MISOption) []T {
+	o := applyMISOptions(opts)
+
+	if o.components {
+		return solveByComponents(ctx, g, opts, func(component Graph[T], opts ...MISOption) []T {
+			return MISGreedySearch(ctx, component, localIters, opts...)
+		})
 	}
 
-	greedySolution := MISGreedy(ctx, graph)
-	if greedySolution == nil || ctx.Err() != nil {
+	s := snapshotOf(g)
+	if s == nil {
 		return nil
 	}
 
-	n := graph.size
+	n := s.Size()
 	genome := make([]bool, n)
-	for _, v := range greedySolution {
-		if ctx.Err() != nil {
-			return nil
+
+	if o.kernelize {
+		lifted := solveOnKernel(ctx, g, append(opts[:len(opts):len(opts)], withImprovementHook(nil)), func(kernel Graph[T], opts ...MISOption) []T {
+			return MISGreedySearch(ctx, kernel, localIters, opts...)
+		})
+		for _, v := range lifted {
+			genome[s.vertexToIndex[v]] = true
+		}
+		localIters = max(localIters, 1)
+	} else {
+		for _, idx := range greedyIndices(ctx, s, o.rng()) {
+			genome[idx] = true
 		}
-		genome[graph.vertexToIndex[v]] = true
 	}
 
-	improved := MISLocalSearch(ctx, g, genome, localIters)
-	if improved == nil || ctx.Err() != nil {
-		return nil
-	}
+	reporter := newImprovementReporter(o, s)
+	improved := localSearch(ctx, s, genome, localIters, reporter.reportGenome)
 
 	result := make([]T, 0, computeCardinality(improved))
 	for i, inc := range improved {
-		if ctx.Err() != nil {
-			return nil
-		}
 		if inc {
-			result = append(result, graph.indexToVertex[i])
+			result = append(result, s.indexToVertex[i])
 		}
 	}
 	return result
@@ -0,0 +1,363 @@
+package graph
+
+import (
+	"context"
+	"math/rand"
+	"slices"
+)
+
+type GreedyRule int
+
+const (
+	DynamicMinDegree GreedyRule = iota
+	StaticMinDegree
+	DegeneracyOrder
+	MaxDegreeRemoval
+	GRASP
+)
+
+func (r GreedyRule) String() string {
+	switch r {
+	case DynamicMinDegree:
+		return "динамическая минимальная степень"
+	case StaticMinDegree:
+		return "статический порядок по степени"
+	case DegeneracyOrder:
+		return "порядок вырожденности"
+	case MaxDegreeRemoval:
+		return "удаление вершин максимальной степени"
+	case GRASP:
+		return "GRASP"
+	}
+	return "неизвестное правило"
+}
+
+type GreedyConfig struct {
+	Rule       GreedyRule
+	RCLAlpha   float64
+	Restarts   int
+	LocalIters int
+}
+
+func MISGreedyVariant[T comparable](ctx context.Context, g Graph[T], cfg GreedyConfig, opts ...MISOption) []T {
+	o := applyMISOptions(opts)
+
+	if o.components {
+		return solveByComponents(ctx, g, opts, func(component Graph[T], opts ...MISOption) []T {
+			return MISGreedyVariant(ctx, component, cfg, opts...)
+		})
+	}
+
+	if o.kernelize {
+		return solveOnKernel(ctx, g, opts, func(kernel Graph[T], opts ...MISOption) []T {
+			return MISGreedyVariant(ctx, kernel, cfg, opts...)
+		})
+	}
+
+	s := snapshotOf(g)
+	if s == nil {
+		return nil
+	}
+
+	reporter := newImprovementReporter(o, s)
+	rng := o.rng()
+
+	var result []int
+	switch cfg.Rule {
+	case StaticMinDegree:
+		result = staticGreedy(ctx, s.CSR(), rng)
+	case DegeneracyOrder:
+		result = degeneracyGreedy(ctx, s.CSR(), rng)
+	case MaxDegreeRemoval:
+		result = reverseGreedy(ctx, s.CSR(), rng)
+	case GRASP:
+		result = grasp(ctx, s, cfg, rng, reporter.reportGenome)
+	default:
+		result = greedyIndices(ctx, s, rng)
+	}
+
+	reporter.reportIndices(result)
+	return s.Vertices(result)
+}
+
+func staticGreedy(ctx context.Context, adj *csrMatrix, rng *rand.Rand) []int {
+	n := adj.Size()
+	order := rng.Perm(n)
+	slices.SortStableFunc(order, func(a, b int) int {
+		return adj.Degree(a) - adj.Degree(b)
+	})
+	return greedyInOrder(ctx, adj, order)
+}
+
+func degeneracyGreedy(ctx context.Context, adj *csrMatrix, rng *rand.Rand) []int {
+	n := adj.Size()
+	removed := make([]bool, n)
+	degree := make([]int, n)
+	for i := range n {
+		for _, j := range adj.Row(i) {
+			if j != i {
+				degree[i]++
+			}
+		}
+	}
+
+	queue := newDegreeBuckets(degree, rng.Perm(n))
+	order := make([]int, 0, n)
+	for range n {
+		if ctx.Err() != nil {
+			break
+		}
+
+		v := queue.popMin(rng)
+		removed[v] = true
+		order = append(order, v)
+		for _, u := range adj.Row(v) {
+			if !removed[u] {
+				queue.decrease(u)
+			}
+		}
+	}
+	return greedyInOrder(ctx, adj, order)
+}
+
+func reverseGreedy(ctx context.Context, adj *csrMatrix, rng *rand.Rand) []int {
+	n := adj.Size()
+	removed := make([]bool, n)
+	degree := make([]int, n)
+	var deleted []int
+	for i := range n {
+		if adj.Get(i, i) {
+			removed[i] = true
+			continue
+		}
+		for _, j := range adj.Row(i) {
+			if !adj.Get(j, j) {
+				degree[i]++
+			}
+		}
+	}
+
+	var alive []int
+	for i := range n {
+		if !removed[i] {
+			alive = append(alive, i)
+		}
+	}
+	rng.Shuffle(len(alive), func(i, j int) { alive[i], alive[j] = alive[j], alive[i] })
+
+	queue := newDegreeBuckets(degree, alive)
+	for queue.maxDegree() > 0 {
+		if ctx.Err() != nil {
+			break
+		}
+
+		v := queue.popMax(rng)
+		removed[v] = true
+		deleted = append(deleted, v)
+		for _, u := range adj.Row(v) {
+			if !removed[u] {
+				queue.decrease(u)
+			}
+		}
+	}
+
+	inSet := make([]bool, n)
+	var result []int
+	for i := range n {
+		if !removed[i] && !slices.ContainsFunc(adj.Row(i), func(u int) bool { return inSet[u] }) {
+			inSet[i] = true
+			result = append(result, i)
+		}
+	}
+	for _, v := range slices.Backward(deleted) {
+		if !slices.ContainsFunc(adj.Row(v), func(u int) bool { return inSet[u] }) {
+			inSet[v] = true
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+func grasp[T comparable](ctx context.Context, s *snapshot[T], cfg GreedyConfig, rng *rand.Rand, report func(genome []bool)) []int {
+	adj := s.CSR()
+	n := adj.Size()
+	budget := budgetOf(ctx)
+
+	var best []int
+	for restart := range max(cfg.Restarts, 1) {
+		if restart > 0 {
+			budget.spend()
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		genome := make([]bool, n)
+		for _, v := range randomizedGreedy(ctx, adj, cfg.RCLAlpha, rng) {
+			genome[v] = true
+		}
+		if cfg.LocalIters > 0 {
+			genome = localSearch(ctx, s, genome, cfg.LocalIters, report)
+		}
+
+		var candidate []int
+		for i, inc := range genome {
+			if inc {
+				candidate = append(candidate, i)
+			}
+		}
+		if len(candidate) > len(best) {
+			best = candidate
+			report(genome)
+		}
+	}
+	return best
+}
+
+func randomizedGreedy(ctx context.Context, adj *csrMatrix, alpha float64, rng *rand.Rand) []int {
+	n := adj.Size()
+	alive := make([]bool, n)
+	degree := make([]int, n)
+	var candidates []int
+	for i := range n {
+		if !adj.Get(i, i) {
+			alive[i] = true
+			candidates = append(candidates, i)
+		}
+	}
+	for _, i := range candidates {
+		for _, j := range adj.Row(i) {
+			if alive[j] {
+				degree[i]++
+			}
+		}
+	}
+
+	var result, rcl []int
+	for len(candidates) > 0 {
+		if ctx.Err() != nil {
+			return result
+		}
+
+		low, high := n, 0
+		for _, v := range candidates {
+			low = min(low, degree[v])
+			high = max(high, degree[v])
+		}
+		threshold := float64(low) + alpha*float64(high-low)
+
+		rcl = rcl[:0]
+		for _, v := range candidates {
+			if float64(degree[v]) <= threshold {
+				rcl = append(rcl, v)
+			}
+		}
+
+		v := rcl[rng.Intn(len(rcl))]
+		result = append(result, v)
+
+		alive[v] = false
+		for _, u := range adj.Row(v) {
+			if !alive[u] {
+				continue
+			}
+			alive[u] = false
+			for _, w := range adj.Row(u) {
+				if alive[w] {
+					degree[w]--
+				}
+			}
+		}
+
+		candidates = slices.DeleteFunc(candidates, func(u int) bool { return !alive[u] })
+	}
+	return result
+}
+
+func greedyInOrder(ctx context.Context, adj *csrMatrix, order []int) []int {
+	blocked := make([]bool, adj.Size())
+	var result []int
+	for _, v := range order {
+		if ctx.Err() != nil {
+			break
+		}
+		if blocked[v] || adj.Get(v, v) {
+			continue
+		}
+		result = append(result, v)
+		blocked[v] = true
+		for _, u := range adj.Row(v) {
+			blocked[u] = true
+		}
+	}
+	return result
+}
+
+type degreeBuckets struct {
+	degree   []int
+	position []int
+	buckets  [][]int
+	low      int
+	high     int
+}
+
+func newDegreeBuckets(degree []int, vertices []int) *degreeBuckets {
+	q := &degreeBuckets{
+		degree:   degree,
+		position: make([]int, len(degree)),
+	}
+	for _, v := range vertices {
+		q.push(v)
+	}
+	return q
+}
+
+func (q *degreeBuckets) push(v int) {
+	d := q.degree[v]
+	for len(q.buckets) <= d {
+		q.buckets = append(q.buckets, nil)
+	}
+	q.position[v] = len(q.buckets[d])
+	q.buckets[d] = append(q.buckets[d], v)
+	q.low = min(q.low, d)
+	q.high = max(q.high, d)
+}
+
+func (q *degreeBuckets) detach(v int) {
+	bucket := q.buckets[q.degree[v]]
+	last := bucket[len(bucket)-1]
+	bucket[q.position[v]] = last
+	q.position[last] = q.position[v]
+	q.buckets[q.degree[v]] = bucket[:len(bucket)-1]
+}
+
+func (q *degreeBuckets) decrease(v int) {
+	q.detach(v)
+	q.degree[v]--
+	q.push(v)
+}
+
+func (q *degreeBuckets) pop(d int, rng *rand.Rand) int {
+	bucket := q.buckets[d]
+	v := bucket[rng.Intn(len(bucket))]
+	q.detach(v)
+	return v
+}
+
+func (q *degreeBuckets) popMin(rng *rand.Rand) int {
+	for len(q.buckets[q.low]) == 0 {
+		q.low++
+	}
+	return q.pop(q.low, rng)
+}
+
+func (q *degreeBuckets) popMax(rng *rand.Rand) int {
+	return q.pop(q.maxDegree(), rng)
+}
+
+func (q *degreeBuckets) maxDegree() int {
+	for q.high > 0 && len(q.buckets[q.high]) == 0 {
+		q.high--
+	}
+	return q.high
+}
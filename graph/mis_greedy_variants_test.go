@@ -0,0 +1,22 @@
+package graph
+
+import (
+	"context"
+	"testing"
+)
+
+func TestReverseGreedyCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	for seed := range int64(20) {
+		g := randomGraph(Undirected, 30, 0.3, seed)
+		result := MISGreedyVariant(ctx, g, GreedyConfig{Rule: MaxDegreeRemoval}, WithSeed(seed))
+		if len(result) == 0 {
+			t.Fatalf("граф %d: пустое решение после отмены", seed)
+		}
+		if check := VerifyIndependentSet(g, result); !check.Valid() {
+			t.Fatalf("граф %d: %s", seed, check)
+		}
+	}
+}
@@ -1,6 +1,9 @@
 package graph
 
-import "slices"
+import (
+	"math"
+	"slices"
+)
 
 func computeCardinality(x []bool) int {
 	count := 0
@@ -34,3 +37,47 @@ func ComputeF1Factor[T comparable](sample, solution []T) float64 {
 
 	return 2 * precision * recall / denominator
 }
+
+type Quality struct {
+	Alpha   int
+	Ratio   float64
+	Gap     int
+	Optimal bool
+	Overlap float64
+}
+
+func ComputeQuality[T comparable](solution []T, optima [][]T) (Quality, bool) {
+	if len(optima) == 0 {
+		return Quality{Ratio: math.NaN(), Overlap: math.NaN()}, false
+	}
+
+	alpha := len(optima[0])
+	q := Quality{
+		Alpha:   alpha,
+		Ratio:   1,
+		Gap:     alpha - len(solution),
+		Optimal: len(solution) >= alpha,
+	}
+	if alpha > 0 {
+		q.Ratio = float64(len(solution)) / float64(alpha)
+	}
+
+	members := make(map[T]bool, len(solution))
+	for _, v := range solution {
+		members[v] = true
+	}
+	for _, optimum := range optima {
+		if len(optimum) == 0 {
+			q.Overlap = 1
+			continue
+		}
+		common := 0
+		for _, v := range optimum {
+			if members[v] {
+				common++
+			}
+		}
+		q.Overlap = max(q.Overlap, float64(common)/float64(len(optimum)))
+	}
+	return q, true
+}
@@ -0,0 +1,122 @@
+package graph
+
+import (
+	"context"
+	"math/rand"
+	"time"
+)
+
+type ILSConfig struct {
+	Iterations   int
+	TimeLimit    time.Duration
+	Restarts     int
+	Perturbation int
+	TabuTenure   int
+}
+
+func MISIteratedLocalSearch[T comparable](ctx context.Context, g Graph[T], cfg ILSConfig, opts ...MISOption) []T {
+	o := applyMISOptions(opts)
+
+	if o.components {
+		return solveByComponents(ctx, g, opts, func(component Graph[T], opts ...MISOption) []T {
+			return MISIteratedLocalSearch(ctx, component, cfg, opts...)
+		})
+	}
+
+	if o.kernelize {
+		return solveOnKernel(ctx, g, opts, func(kernel Graph[T], opts ...MISOption) []T {
+			return MISIteratedLocalSearch(ctx, kernel, cfg, opts...)
+		})
+	}
+
+	s := snapshotOf(g)
+	if s == nil {
+		return nil
+	}
+
+	searchCtx := ctx
+	if cfg.TimeLimit > 0 {
+		var cancel context.CancelFunc
+		searchCtx, cancel = context.WithTimeout(ctx, cfg.TimeLimit)
+		defer cancel()
+	}
+
+	reporter := newImprovementReporter(o, s)
+	best := iteratedLocalSearch(searchCtx, s, cfg, o.rng(), reporter.reportGenome)
+
+	result := make([]T, 0, computeCardinality(best))
+	for i, inc := range best {
+		if inc {
+			result = append(result, s.indexToVertex[i])
+		}
+	}
+	return result
+}
+
+func iteratedLocalSearch[T comparable](ctx context.Context, s *snapshot[T], cfg ILSConfig, rng *rand.Rand, report func(genome []bool)) []bool {
+	n := s.Size()
+	if n == 0 {
+		return []bool{}
+	}
+	if cfg.Iterations <= 0 {
+		cfg.Iterations = n
+	}
+	if cfg.Perturbation <= 0 {
+		cfg.Perturbation = 1
+	}
+	if cfg.TabuTenure < 0 {
+		cfg.TabuTenure = 0
+	}
+
+	ls := newSwapSearch(s)
+	restart := func() {
+		ls.load(make([]bool, n))
+		for _, v := range greedyIndices(ctx, s, rng) {
+			ls.insert(v)
+		}
+		for ls.twoImprove(ctx) {
+		}
+	}
+
+	restart()
+
+	best := ls.genome()
+	bestSize := ls.size
+	report(best)
+	segment := cfg.Iterations / (max(cfg.Restarts, 0) + 1)
+	budget := budgetOf(ctx)
+
+	for i := 1; i <= cfg.Iterations; i++ {
+		budget.spend()
+		if ctx.Err() != nil {
+			break
+		}
+
+		if segment > 0 && i%segment == 0 && i < cfg.Iterations {
+			restart()
+		} else {
+			current := ls.genome()
+			currentSize := ls.size
+
+			ls.perturb(rng, cfg.Perturbation, cfg.TabuTenure)
+			for ls.twoImprove(ctx) {
+			}
+
+			if ls.size < currentSize-1 || (ls.size < currentSize && rng.Intn(2) == 0) {
+				ls.load(current)
+			}
+		}
+
+		if ls.size > bestSize {
+			best = ls.genome()
+			bestSize = ls.size
+			report(best)
+		}
+	}
+
+	ls.load(best)
+	ls.iteration = cfg.Iterations + cfg.TabuTenure
+	ls.fillFree(nil)
+	report(ls.inSolution)
+	return ls.genome()
+}
@@ -0,0 +1,541 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+type Kernel[T comparable] struct {
+	Graph  Graph[T]
+	Offset int
+	Lift   func(solution []T) []T
+}
+
+func Kernelize[T comparable](ctx context.Context, g Graph[T]) *Kernel[T] {
+	return kernelize(ctx, g, false)
+}
+
+func KernelizeWeighted[T comparable](ctx context.Context, g Graph[T]) *Kernel[T] {
+	return kernelize(ctx, g, true)
+}
+
+func kernelize[T comparable](ctx context.Context, g Graph[T], weighted bool) *Kernel[T] {
+	s := snapshotOf(g)
+	if s == nil {
+		return nil
+	}
+
+	k := newKernelizer(s.CSR())
+	if weighted {
+		k.weights = s.weights
+		for v := range k.adj {
+			if k.alive[v] && k.weights[v] <= 0 {
+				k.remove(v)
+			}
+		}
+	}
+	if !k.reduce(ctx) {
+		return nil
+	}
+
+	kernel := NewGraph[T](Undirected)
+	for v := range k.adj {
+		if k.alive[v] {
+			kernel.AddVertex(&s.indexToVertex[v])
+			if weighted {
+				kernel.SetVertexWeight(&s.indexToVertex[v], s.Weight(v))
+			}
+		}
+	}
+	for v := range k.adj {
+		if !k.alive[v] {
+			continue
+		}
+		for _, u := range sortedKeys(k.adj[v]) {
+			if v < u {
+				kernel.AddEdge(&s.indexToVertex[v], &s.indexToVertex[u])
+			}
+		}
+	}
+
+	log := slices.Clone(k.log)
+
+	return &Kernel[T]{
+		Graph:  kernel,
+		Offset: k.offset,
+		Lift: func(solution []T) []T {
+			selected := make(map[int]bool, len(solution))
+			for _, v := range solution {
+				if idx, ok := s.vertexToIndex[v]; ok {
+					selected[idx] = true
+				}
+			}
+
+			for i := len(log) - 1; i >= 0; i-- {
+				op := log[i]
+				if op.center < 0 || selected[op.center] {
+					delete(selected, op.center)
+					for _, v := range op.ifIn {
+						selected[v] = true
+					}
+				} else {
+					for _, v := range op.ifOut {
+						selected[v] = true
+					}
+				}
+			}
+
+			indices := make([]int, 0, len(selected))
+			for idx := range selected {
+				indices = append(indices, idx)
+			}
+			slices.Sort(indices)
+			return s.Vertices(indices)
+		},
+	}
+}
+
+func solveOnKernel[T comparable](ctx context.Context, g Graph[T], opts []MISOption, solve func(kernel Graph[T], opts ...MISOption) []T) []T {
+	o := applyMISOptions(opts)
+
+	var kernel *Kernel[T]
+	if o.weighted {
+		kernel = KernelizeWeighted(ctx, g)
+	} else {
+		kernel = Kernelize(ctx, g)
+	}
+	if kernel == nil {
+		return fallbackSolution(g, o)
+	}
+
+	reporter := newImprovementReporter(o, snapshotOf(g))
+
+	var solution []T
+	if kernel.Graph.Size() > 0 {
+		kernelOpts := []MISOption{withImprovementHook(nil)}
+		if o.seeded {
+			kernelOpts = append(kernelOpts, WithSeed(o.seed))
+		}
+		if o.weighted {
+			kernelOpts = withWeightedObjective(kernelOpts)
+		}
+		if reporter != nil {
+			kernelOpts = append(kernelOpts, WithImprovements(func(imp Improvement[T]) {
+				reporter.report(kernel.Lift(imp.Vertices))
+			}))
+		}
+
+		solution = solve(kernel.Graph, kernelOpts...)
+		if solution == nil {
+			return fallbackSolution(g, o)
+		}
+	}
+
+	lifted := kernel.Lift(solution)
+	reporter.report(lifted)
+	return lifted
+}
+
+type reductionOp struct {
+	center int
+	ifIn   []int
+	ifOut  []int
+}
+
+type kernelizer struct {
+	adj     []map[int]struct{}
+	alive   []bool
+	weights []float64
+	log     []reductionOp
+	offset  int
+}
+
+func newKernelizer(csr *csrMatrix) *kernelizer {
+	k := &kernelizer{
+		adj:   make([]map[int]struct{}, csr.Size()),
+		alive: make([]bool, csr.Size()),
+	}
+
+	for v := range csr.Size() {
+		k.alive[v] = true
+		k.adj[v] = make(map[int]struct{}, csr.Degree(v))
+		for _, u := range csr.Row(v) {
+			k.adj[v][u] = struct{}{}
+		}
+	}
+
+	for v := range csr.Size() {
+		if _, loop := k.adj[v][v]; loop {
+			k.remove(v)
+		}
+	}
+
+	return k
+}
+
+func (k *kernelizer) reduce(ctx context.Context) bool {
+	for {
+		if ctx.Err() != nil {
+			return false
+		}
+
+		if k.weights != nil {
+			if !k.reduceWeightedNeighborhood() && !k.reduceWeightedDomination() {
+				return true
+			}
+			continue
+		}
+
+		if k.reduceLowDegree() || k.reduceDomination() || k.reduceTwins() {
+			continue
+		}
+
+		if ctx.Err() != nil {
+			return false
+		}
+
+		if !k.reduceCrown() {
+			return true
+		}
+	}
+}
+
+func (k *kernelizer) remove(v int) {
+	for u := range k.adj[v] {
+		delete(k.adj[u], v)
+	}
+	k.adj[v] = nil
+	k.alive[v] = false
+}
+
+func (k *kernelizer) include(v int) {
+	for u := range k.adj[v] {
+		k.remove(u)
+	}
+	k.remove(v)
+	k.log = append(k.log, reductionOp{center: -1, ifIn: []int{v}})
+	k.offset++
+}
+
+func (k *kernelizer) fold(center int, members, alternative []int) {
+	neighborhood := make(map[int]struct{})
+	for _, m := range members {
+		for u := range k.adj[m] {
+			neighborhood[u] = struct{}{}
+		}
+	}
+
+	for _, v := range members {
+		k.remove(v)
+	}
+	for _, v := range alternative {
+		if v != center {
+			k.remove(v)
+		}
+	}
+	for u := range k.adj[center] {
+		delete(k.adj[u], center)
+	}
+
+	delete(neighborhood, center)
+	for u := range neighborhood {
+		if !k.alive[u] {
+			delete(neighborhood, u)
+		}
+	}
+	k.adj[center] = neighborhood
+	for u := range neighborhood {
+		k.adj[u][center] = struct{}{}
+	}
+
+	k.log = append(k.log, reductionOp{center: center, ifIn: members, ifOut: alternative})
+	k.offset += len(alternative)
+}
+
+func (k *kernelizer) reduceLowDegree() bool {
+	changed := false
+	for v := range k.adj {
+		if !k.alive[v] {
+			continue
+		}
+
+		switch len(k.adj[v]) {
+		case 0, 1:
+			k.include(v)
+			changed = true
+		case 2:
+			var u, w int
+			i := 0
+			for n := range k.adj[v] {
+				if i == 0 {
+					u = n
+				} else {
+					w = n
+				}
+				i++
+			}
+			if _, adjacent := k.adj[u][w]; adjacent {
+				k.include(v)
+			} else {
+				k.fold(v, []int{u, w}, []int{v})
+			}
+			changed = true
+		}
+	}
+	return changed
+}
+
+func (k *kernelizer) reduceDomination() bool {
+	changed := false
+	for v := range k.adj {
+		if !k.alive[v] {
+			continue
+		}
+
+		for u := range k.adj[v] {
+			if len(k.adj[u]) > len(k.adj[v]) || !k.closedSubset(u, v) {
+				continue
+			}
+			k.remove(v)
+			changed = true
+			break
+		}
+	}
+	return changed
+}
+
+func (k *kernelizer) reduceWeightedNeighborhood() bool {
+	changed := false
+	for v := range k.adj {
+		if !k.alive[v] {
+			continue
+		}
+
+		neighborhood := 0.0
+		for u := range k.adj[v] {
+			neighborhood += k.weights[u]
+		}
+		if k.weights[v] >= neighborhood {
+			k.include(v)
+			changed = true
+		}
+	}
+	return changed
+}
+
+func (k *kernelizer) reduceWeightedDomination() bool {
+	changed := false
+	for v := range k.adj {
+		if !k.alive[v] {
+			continue
+		}
+
+		for u := range k.adj[v] {
+			if k.weights[u] < k.weights[v] || len(k.adj[u]) > len(k.adj[v]) || !k.closedSubset(u, v) {
+				continue
+			}
+			k.remove(v)
+			changed = true
+			break
+		}
+	}
+	return changed
+}
+
+func (k *kernelizer) closedSubset(u, v int) bool {
+	for w := range k.adj[u] {
+		if w == v {
+			continue
+		}
+		if _, ok := k.adj[v][w]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (k *kernelizer) reduceTwins() bool {
+	groups := make(map[string][]int)
+	for v := range k.adj {
+		if !k.alive[v] || len(k.adj[v]) != 3 {
+			continue
+		}
+		key := neighborhoodKey(k.adj[v])
+		groups[key] = append(groups[key], v)
+	}
+
+	keys := make([]string, 0, len(groups))
+	for key := range groups {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		twins := groups[key]
+		if len(twins) < 2 || !k.alive[twins[0]] || !k.alive[twins[1]] {
+			continue
+		}
+
+		u, v := twins[0], twins[1]
+		neighbors := sortedKeys(k.adj[u])
+
+		if k.hasEdgeAmong(neighbors) {
+			k.include(u)
+			k.include(v)
+		} else {
+			k.fold(u, neighbors, []int{u, v})
+		}
+		return true
+	}
+	return false
+}
+
+func (k *kernelizer) hasEdgeAmong(vertices []int) bool {
+	for i, a := range vertices {
+		for _, b := range vertices[i+1:] {
+			if _, ok := k.adj[a][b]; ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (k *kernelizer) reduceCrown() bool {
+	n := len(k.adj)
+
+	neighbors := make([][]int, n)
+	for v := range k.adj {
+		if k.alive[v] {
+			neighbors[v] = sortedKeys(k.adj[v])
+		}
+	}
+
+	matchL, matchR := hopcroftKarp(neighbors, n)
+
+	visitedL := make([]bool, n)
+	visitedR := make([]bool, n)
+	queue := make([]int, 0, n)
+	for v := range n {
+		if k.alive[v] && matchL[v] == -1 {
+			visitedL[v] = true
+			queue = append(queue, v)
+		}
+	}
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+		for _, u := range neighbors[v] {
+			if visitedR[u] {
+				continue
+			}
+			visitedR[u] = true
+			if w := matchR[u]; w != -1 && !visitedL[w] {
+				visitedL[w] = true
+				queue = append(queue, w)
+			}
+		}
+	}
+
+	var forced []int
+	for v := range n {
+		if !k.alive[v] {
+			continue
+		}
+		inCoverL := !visitedL[v]
+		inCoverR := visitedR[v]
+		if !inCoverL && !inCoverR {
+			forced = append(forced, v)
+		}
+	}
+
+	for _, v := range forced {
+		if k.alive[v] {
+			k.include(v)
+		}
+	}
+	return len(forced) > 0
+}
+
+func hopcroftKarp(neighbors [][]int, n int) ([]int, []int) {
+	const inf = int(^uint(0) >> 1)
+
+	matchL := make([]int, n)
+	matchR := make([]int, n)
+	for i := range n {
+		matchL[i] = -1
+		matchR[i] = -1
+	}
+	dist := make([]int, n)
+
+	bfs := func() bool {
+		queue := make([]int, 0, n)
+		found := false
+		for v := range n {
+			if matchL[v] == -1 && len(neighbors[v]) > 0 {
+				dist[v] = 0
+				queue = append(queue, v)
+			} else {
+				dist[v] = inf
+			}
+		}
+		for len(queue) > 0 {
+			v := queue[0]
+			queue = queue[1:]
+			for _, u := range neighbors[v] {
+				w := matchR[u]
+				if w == -1 {
+					found = true
+				} else if dist[w] == inf {
+					dist[w] = dist[v] + 1
+					queue = append(queue, w)
+				}
+			}
+		}
+		return found
+	}
+
+	var dfs func(v int) bool
+	dfs = func(v int) bool {
+		for _, u := range neighbors[v] {
+			w := matchR[u]
+			if w == -1 || (dist[w] == dist[v]+1 && dfs(w)) {
+				matchL[v] = u
+				matchR[u] = v
+				return true
+			}
+		}
+		dist[v] = inf
+		return false
+	}
+
+	for bfs() {
+		for v := range n {
+			if matchL[v] == -1 && len(neighbors[v]) > 0 {
+				dfs(v)
+			}
+		}
+	}
+
+	return matchL, matchR
+}
+
+func sortedKeys(set map[int]struct{}) []int {
+	keys := make([]int, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+func neighborhoodKey(set map[int]struct{}) string {
+	keys := sortedKeys(set)
+	parts := make([]string, len(keys))
+	for i, key := range keys {
+		parts[i] = fmt.Sprint(key)
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,140 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+func buildGraph(n int, edges [][2]int) Graph[string] {
+	g := NewGraph[string](Undirected)
+	vertices := make([]string, n)
+	for i := range vertices {
+		vertices[i] = fmt.Sprint("v", i)
+		g.AddVertex(&vertices[i])
+	}
+	for _, e := range edges {
+		g.AddEdge(&vertices[e[0]], &vertices[e[1]])
+	}
+	return g
+}
+
+func pathEdges(n int) [][2]int {
+	var edges [][2]int
+	for i := 1; i < n; i++ {
+		edges = append(edges, [2]int{i - 1, i})
+	}
+	return edges
+}
+
+func cycleEdges(n int) [][2]int {
+	return append(pathEdges(n), [2]int{n - 1, 0})
+}
+
+func bruteForceOptima(g Graph[string]) [][]string {
+	vertices := g.GetAllVertices()
+	index := make(map[string]int, len(vertices))
+	for i, v := range vertices {
+		index[v] = i
+	}
+	conflicts := make([]uint32, len(vertices))
+	for _, e := range g.GetAllEdges() {
+		from, to := index[*e.From], index[*e.To]
+		conflicts[from] |= 1 << to
+		conflicts[to] |= 1 << from
+	}
+
+	var optima [][]string
+	best := -1
+	for mask := uint32(0); mask < 1<<len(vertices); mask++ {
+		var set []string
+		for i, v := range vertices {
+			if mask>>i&1 == 0 {
+				continue
+			}
+			if conflicts[i]&mask != 0 {
+				set = nil
+				break
+			}
+			set = append(set, v)
+		}
+		if set == nil && mask != 0 {
+			continue
+		}
+		if len(set) > best {
+			best = len(set)
+			optima = nil
+		}
+		if len(set) == best {
+			optima = append(optima, set)
+		}
+	}
+	return optima
+}
+
+func bruteForceAlpha(g Graph[string]) int {
+	return len(bruteForceOptima(g)[0])
+}
+
+func TestKernelize(t *testing.T) {
+	tests := []struct {
+		name string
+		g    Graph[string]
+	}{
+		{"пустой граф", buildGraph(0, nil)},
+		{"изолированные вершины", buildGraph(4, nil)},
+		{"путь P7", buildGraph(7, pathEdges(7))},
+		{"путь P10", buildGraph(10, pathEdges(10))},
+		{"цикл C5", buildGraph(5, cycleEdges(5))},
+		{"цикл C8", buildGraph(8, cycleEdges(8))},
+		{"звезда K1,6", buildGraph(7, [][2]int{{0, 1}, {0, 2}, {0, 3}, {0, 4}, {0, 5}, {0, 6}})},
+		{"корона", buildGraph(8, [][2]int{
+			{0, 3}, {0, 4}, {1, 4}, {2, 5},
+			{3, 4}, {4, 5}, {3, 5},
+			{3, 6}, {5, 7}, {6, 7},
+		})},
+		{"близнецы", buildGraph(9, [][2]int{
+			{0, 2}, {0, 3}, {0, 4}, {1, 2}, {1, 3}, {1, 4},
+			{2, 3}, {2, 5}, {3, 6}, {4, 7}, {4, 8}, {5, 6}, {7, 8},
+		})},
+		{"граф Петерсена", buildGraph(10, [][2]int{
+			{0, 1}, {1, 2}, {2, 3}, {3, 4}, {4, 0},
+			{0, 5}, {1, 6}, {2, 7}, {3, 8}, {4, 9},
+			{5, 7}, {7, 9}, {9, 6}, {6, 8}, {8, 5},
+		})},
+		{"полный граф K5", buildGraph(5, [][2]int{{0, 1}, {0, 2}, {0, 3}, {0, 4}, {1, 2}, {1, 3}, {1, 4}, {2, 3}, {2, 4}, {3, 4}})},
+	}
+	for seed := range int64(30) {
+		tests = append(tests, struct {
+			name string
+			g    Graph[string]
+		}{fmt.Sprint("случайный граф ", seed), randomGraph(Undirected, 10+int(seed)%7, 0.15+0.03*float64(seed%12), seed)})
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			alpha := bruteForceAlpha(tt.g)
+
+			kernel := Kernelize(context.Background(), tt.g)
+			if kernel == nil {
+				t.Fatal("ядро не построено")
+			}
+			if kernel.Graph.Size() > tt.g.Size() {
+				t.Fatalf("ядро из %d вершин больше графа из %d", kernel.Graph.Size(), tt.g.Size())
+			}
+
+			solution := bruteForceOptima(kernel.Graph)[0]
+			if got := kernel.Offset + len(solution); got != alpha {
+				t.Fatalf("смещение %d + α ядра %d = %d, ожидалось %d", kernel.Offset, len(solution), got, alpha)
+			}
+
+			lifted := kernel.Lift(solution)
+			if len(lifted) != alpha {
+				t.Fatalf("восстановлено %d вершин, ожидалось %d", len(lifted), alpha)
+			}
+			if check := VerifyIndependentSet(tt.g, lifted); !check.Independent() {
+				t.Fatal(check)
+			}
+		})
+	}
+}
@@ -2,53 +2,197 @@ package graph
 
 import (
 	"context"
-	"slices"
+	"math/rand"
 )
 
-func MISLocalSearch[T comparable](ctx context.Context, g Graph[T], genome []bool, localIters int) []bool {
-	graph, ok := g.(*graph[T])
-	if !ok {
+func MISLocalSearch[T comparable](ctx context.Context, g Graph[T], genome []bool, localIters int, opts ...MISOption) []bool {
+	s := snapshotOf(g)
+	if s == nil {
 		return nil
 	}
 
-	initAdjMatrix(graph)
-	adj := graph.cache.AdjMatrix
-	best := slices.Clone(genome)
-	bestFitness := computeCardinality(best)
-	n := len(genome)
+	reporter := newImprovementReporter(applyMISOptions(opts), s)
+	return localSearch(ctx, s, genome, localIters, reporter.reportGenome)
+}
+
+func localSearch[T comparable](ctx context.Context, s *snapshot[T], genome []bool, localIters int, report func(genome []bool)) []bool {
+	ls := newSwapSearch(s)
+	for i, inc := range genome {
+		if inc && !ls.blocked[i] && ls.tight[i] == 0 {
+			ls.insert(i)
+		}
+	}
+	ls.fillFree(nil)
+	report(ls.inSolution)
 
+	budget := budgetOf(ctx)
 	for range localIters {
-		if ctx.Err() != nil {
-			return nil
+		budget.spend()
+		if ctx.Err() != nil || !ls.twoImprove(ctx) {
+			break
 		}
+		report(ls.inSolution)
+	}
+
+	return ls.genome()
+}
+
+type swapSearch struct {
+	csr    *csrMatrix
+	matrix *adjMatrix
+
+	inSolution []bool
+	blocked    []bool
+	tight      []int
+	tabuUntil  []int
+	iteration  int
+	size       int
+}
+
+func newSwapSearch[T comparable](s *snapshot[T]) *swapSearch {
+	n := s.Size()
+	ls := &swapSearch{
+		csr:        s.CSR(),
+		inSolution: make([]bool, n),
+		blocked:    make([]bool, n),
+		tight:      make([]int, n),
+		tabuUntil:  make([]int, n),
+	}
+	if s.dense {
+		ls.matrix = s.Matrix()
+	}
 
-		improved := false
-		for j := range n {
-			temp := slices.Clone(best)
-			temp[j] = !temp[j]
+	for v := range n {
+		ls.blocked[v] = ls.connected(v, v)
+	}
+	return ls
+}
+
+func (ls *swapSearch) connected(u, v int) bool {
+	if ls.matrix != nil {
+		return ls.matrix.Get(u, v)
+	}
+	return ls.csr.Get(u, v)
+}
+
+func (ls *swapSearch) insertable(v int) bool {
+	return !ls.inSolution[v] && !ls.blocked[v] && ls.tight[v] == 0 && ls.tabuUntil[v] <= ls.iteration
+}
 
-			if checkSolutionFast(adj, temp, j) {
-				currentFitness := computeCardinality(temp)
-				if currentFitness > bestFitness {
-					best = temp
-					bestFitness = currentFitness
-					improved = true
-				}
+func (ls *swapSearch) insert(v int) {
+	ls.inSolution[v] = true
+	ls.size++
+	for _, u := range ls.csr.Row(v) {
+		ls.tight[u]++
+	}
+}
+
+func (ls *swapSearch) remove(v int) {
+	ls.inSolution[v] = false
+	ls.size--
+	for _, u := range ls.csr.Row(v) {
+		ls.tight[u]--
+	}
+}
+
+func (ls *swapSearch) fillFree(candidates []int) {
+	if candidates == nil {
+		for v := range ls.inSolution {
+			if ls.insertable(v) {
+				ls.insert(v)
 			}
 		}
+		return
+	}
 
-		if !improved {
-			break
+	for _, v := range candidates {
+		if ls.insertable(v) {
+			ls.insert(v)
 		}
 	}
-	return best
 }
 
-func checkSolutionFast(adj *adjMatrix, genome []bool, flippedIdx int) bool {
-	for j := range adj.Size() {
-		if genome[j] && (adj.Get(flippedIdx, j) || adj.Get(j, flippedIdx)) {
+func (ls *swapSearch) twoImprove(ctx context.Context) bool {
+	improved := false
+	for x := range ls.inSolution {
+		if ctx.Err() != nil {
 			return false
 		}
+		if ls.inSolution[x] && ls.swap(x) {
+			improved = true
+		}
+	}
+	return improved
+}
+
+func (ls *swapSearch) swap(x int) bool {
+	var candidates []int
+	for _, u := range ls.csr.Row(x) {
+		if !ls.inSolution[u] && !ls.blocked[u] && ls.tight[u] == 1 && ls.tabuUntil[u] <= ls.iteration {
+			candidates = append(candidates, u)
+		}
+	}
+	if len(candidates) < 2 {
+		return false
+	}
+
+	for i, u := range candidates {
+		for _, w := range candidates[i+1:] {
+			if ls.connected(u, w) {
+				continue
+			}
+
+			ls.remove(x)
+			ls.insert(u)
+			ls.insert(w)
+			ls.fillFree(ls.csr.Row(x))
+			return true
+		}
+	}
+	return false
+}
+
+func (ls *swapSearch) perturb(rng *rand.Rand, strength, tenure int) {
+	ls.iteration++
+
+	n := len(ls.inSolution)
+	for range strength {
+		v := rng.Intn(n)
+		if ls.inSolution[v] || ls.blocked[v] || ls.tabuUntil[v] > ls.iteration {
+			continue
+		}
+
+		var removed []int
+		for _, u := range ls.csr.Row(v) {
+			if ls.inSolution[u] {
+				ls.remove(u)
+				ls.tabuUntil[u] = ls.iteration + tenure
+				removed = append(removed, u)
+			}
+		}
+		ls.insert(v)
+
+		for _, u := range removed {
+			ls.fillFree(ls.csr.Row(u))
+		}
+	}
+}
+
+func (ls *swapSearch) genome() []bool {
+	genome := make([]bool, len(ls.inSolution))
+	copy(genome, ls.inSolution)
+	return genome
+}
+
+func (ls *swapSearch) load(genome []bool) {
+	for v := range ls.inSolution {
+		if ls.inSolution[v] {
+			ls.remove(v)
+		}
+	}
+	for v, inc := range genome {
+		if inc {
+			ls.insert(v)
+		}
 	}
-	return true
 }
@@ -0,0 +1,90 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func genomeVertices(g Graph[string], genome []bool) []string {
+	var indices []int
+	for i, inc := range genome {
+		if inc {
+			indices = append(indices, i)
+		}
+	}
+	return snapshotOf(g).Vertices(indices)
+}
+
+func TestLocalSearchIndependent(t *testing.T) {
+	for seed := range int64(30) {
+		n := 5 + int(seed)%40
+		p := []float64{0.05, 0.15, 0.3, 0.6}[seed%4]
+		g := randomGraph(Undirected, n, p, seed)
+		rng := rand.New(rand.NewSource(seed))
+
+		t.Run(fmt.Sprintf("n=%d p=%.2f", n, p), func(t *testing.T) {
+			genome := make([]bool, n)
+			for i := range genome {
+				genome[i] = rng.Intn(3) == 0
+			}
+			result := genomeVertices(g, MISLocalSearch(context.Background(), g, genome, 50))
+			if check := VerifyIndependentSet(g, result); !check.Valid() {
+				t.Fatalf("произвольное начальное решение: %s", check)
+			}
+
+			greedy := greedyIndices(context.Background(), snapshotOf(g), rng)
+			genome = make([]bool, n)
+			for _, idx := range greedy {
+				genome[idx] = true
+			}
+			result = genomeVertices(g, MISLocalSearch(context.Background(), g, genome, 50))
+			if check := VerifyIndependentSet(g, result); !check.Valid() {
+				t.Fatalf("жадное начальное решение: %s", check)
+			}
+			if len(result) < len(greedy) {
+				t.Fatalf("локальный поиск уменьшил решение с %d до %d вершин", len(greedy), len(result))
+			}
+		})
+	}
+}
+
+func TestIteratedLocalSearchIndependent(t *testing.T) {
+	configs := []struct {
+		name string
+		cfg  ILSConfig
+		opts []MISOption
+	}{
+		{"по умолчанию", ILSConfig{Iterations: 100}, nil},
+		{"с перезапусками", ILSConfig{Iterations: 50, Restarts: 3, Perturbation: 2, TabuTenure: 5}, nil},
+		{"сильное возмущение", ILSConfig{Iterations: 50, Perturbation: 10, TabuTenure: 1}, nil},
+		{"по компонентам", ILSConfig{Iterations: 50}, []MISOption{WithComponentDecomposition(2)}},
+		{"с ядром", ILSConfig{Iterations: 50}, []MISOption{WithKernelization()}},
+	}
+
+	for seed := range int64(12) {
+		n := 8 + int(seed)%9
+		p := []float64{0.1, 0.25, 0.4, 0.6}[seed%4]
+		g := randomGraph(Undirected, n, p, seed)
+		alpha := bruteForceAlpha(g)
+
+		for _, c := range configs {
+			t.Run(fmt.Sprintf("%s, n=%d p=%.2f", c.name, n, p), func(t *testing.T) {
+				opts := append([]MISOption{WithSeed(seed)}, c.opts...)
+				result := MISIteratedLocalSearch(context.Background(), g, c.cfg, opts...)
+				if check := VerifyIndependentSet(g, result); !check.Valid() {
+					t.Fatal(check)
+				}
+				if len(result) > alpha {
+					t.Fatalf("найдено %d вершин при α = %d", len(result), alpha)
+				}
+
+				result = MISGreedySearch(context.Background(), g, c.cfg.Iterations, opts...)
+				if check := VerifyIndependentSet(g, result); !check.Valid() {
+					t.Fatalf("жадный поиск: %s", check)
+				}
+			})
+		}
+	}
+}
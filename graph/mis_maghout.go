@@ -2,118 +2,223 @@ package graph
 
 import (
 	"context"
+	"slices"
 	"sync"
-	"sync/atomic"
 )
 
-func MISMaghout[T comparable](ctx context.Context, g Graph[T], parallelDepth int) []T {
-	graph, ok := g.(*graph[T])
-	if !ok || graph == nil {
+type MaghoutResult[T comparable] struct {
+	MaximalSets        [][]T
+	IndependenceNumber int
+}
+
+func MISMaghout[T comparable](ctx context.Context, g Graph[T], parallelDepth int, opts ...MISOption) []T {
+	o := applyMISOptions(opts)
+
+	if o.components {
+		return solveByComponents(ctx, g, opts, func(component Graph[T], opts ...MISOption) []T {
+			return MISMaghout(ctx, component, parallelDepth, opts...)
+		})
+	}
+
+	if o.kernelize {
+		return solveOnKernel(ctx, g, opts, func(kernel Graph[T], opts ...MISOption) []T {
+			return MISMaghout(ctx, kernel, parallelDepth, opts...)
+		})
+	}
+
+	s := snapshotOf(g)
+	if s == nil {
 		return nil
 	}
 
-	if graph.cache.AdjMatrix == nil {
-		initAdjMatrix(graph)
+	result := MaghoutMaximalSets(ctx, g, parallelDepth, opts...)
+	if result == nil || len(result.MaximalSets) == 0 {
+		if s.Size() == 0 {
+			return nil
+		}
+		return s.Vertices(fallbackIndices(s, o))
+	}
+	return result.MaximalSets[0]
+}
+
+func MaghoutMaximalSets[T comparable](ctx context.Context, g Graph[T], parallelDepth int, opts ...MISOption) *MaghoutResult[T] {
+	o := applyMISOptions(opts)
+
+	s := snapshotOf(g)
+	if s == nil {
+		return nil
 	}
 
-	n := graph.size
+	n := s.Size()
 	if n == 0 {
 		return nil
 	}
 
-	if parallelDepth <= 0 || parallelDepth > n {
-		parallelDepth = n
+	reporter := newImprovementReporter(o, s)
+	if reporter != nil {
+		reporter.reportIndices(greedyIndices(ctx, s, o.rng()))
+	}
+
+	edges := collectEdgeFactors(s.Matrix())
+
+	if parallelDepth < 0 {
+		parallelDepth = 0
 	}
 	if parallelDepth > 16 {
 		parallelDepth = 16
 	}
 
-	total := 1 << parallelDepth
+	blocks := 1 << parallelDepth
+	if blocks > len(edges) {
+		blocks = max(len(edges), 1)
+	}
 
+	products := make([][]bitset, blocks)
 	var wg sync.WaitGroup
-	var bestCount int32 = -1
-	var bestSolution atomic.Value
-	bestSolution.Store(make([]bool, n))
+	for b := range blocks {
+		lo := b * len(edges) / blocks
+		hi := (b + 1) * len(edges) / blocks
 
-	for mask := range total {
-		mask := mask
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
+			products[b] = multiplyEdgeFactors(ctx, n, edges[lo:hi])
+		}()
+	}
+	wg.Wait()
 
-			select {
-			case <-ctx.Done():
-				return
-			default:
-			}
+	for len(products) > 1 {
+		if ctx.Err() != nil {
+			return nil
+		}
 
-			current := make([]bool, n)
-			for i := range parallelDepth {
-				current[i] = (mask>>i)&1 == 1
+		merged := make([][]bitset, (len(products)+1)/2)
+		for i := range merged {
+			if 2*i+1 == len(products) {
+				merged[i] = products[2*i]
+				continue
 			}
 
-			backtrack(ctx, graph, current, parallelDepth, &bestCount, &bestSolution)
-		}()
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				merged[i] = multiplyDNF(ctx, products[2*i], products[2*i+1])
+			}()
+		}
+		wg.Wait()
+		products = merged
 	}
 
-	wg.Wait()
-
 	if ctx.Err() != nil {
 		return nil
 	}
 
-	solution := bestSolution.Load().([]bool)
-	result := make([]T, 0, n)
-	for i, include := range solution {
-		if include {
-			result = append(result, graph.indexToVertex[i])
+	covers := products[0]
+	slices.SortFunc(covers, func(a, b bitset) int {
+		if d := a.Count() - b.Count(); d != 0 {
+			return d
+		}
+		return slices.Compare(a, b)
+	})
+
+	result := &MaghoutResult[T]{
+		MaximalSets: make([][]T, 0, len(covers)),
+	}
+	for _, cover := range covers {
+		set := make([]T, 0, n-cover.Count())
+		for i := range n {
+			if !cover.Has(i) {
+				set = append(set, s.indexToVertex[i])
+			}
 		}
+		result.MaximalSets = append(result.MaximalSets, set)
 	}
+	result.IndependenceNumber = len(result.MaximalSets[0])
+
+	reporter.report(result.MaximalSets[0])
 	return result
 }
 
-func backtrack[T comparable](
-	ctx context.Context,
-	g *graph[T],
-	current []bool,
-	idx int,
-	bestCount *int32,
-	bestSolution *atomic.Value,
-) {
-	if ctx.Err() != nil {
-		return
+func collectEdgeFactors(adj *adjMatrix) [][2]int {
+	var edges [][2]int
+	for i := range adj.Size() {
+		adj.Row(i).ForEach(adj.Size(), func(j int) {
+			if j >= i {
+				edges = append(edges, [2]int{i, j})
+			}
+		})
 	}
+	return edges
+}
+
+func multiplyEdgeFactors(ctx context.Context, n int, edges [][2]int) []bitset {
+	terms := []bitset{newBitset(n)}
+	budget := budgetOf(ctx)
+
+	for _, edge := range edges {
+		budget.spend()
+		if ctx.Err() != nil {
+			return nil
+		}
 
-	if idx == len(current) {
-		if checkSolution(g, current) {
-			count := int32(computeCardinality(current))
-			if count > atomic.LoadInt32(bestCount) {
-				newSol := make([]bool, len(current))
-				copy(newSol, current)
-				atomic.StoreInt32(bestCount, count)
-				bestSolution.Store(newSol)
+		i, j := edge[0], edge[1]
+		next := make([]bitset, 0, len(terms)*2)
+		for _, term := range terms {
+			if term.Has(i) || term.Has(j) {
+				next = append(next, term)
+				continue
+			}
+
+			withI := term.Clone()
+			withI.Set(i)
+			next = append(next, withI)
+
+			if i != j {
+				withJ := term.Clone()
+				withJ.Set(j)
+				next = append(next, withJ)
 			}
 		}
-		return
+		terms = absorb(ctx, next)
 	}
 
-	current[idx] = false
-	backtrack(ctx, g, current, idx+1, bestCount, bestSolution)
-
-	current[idx] = true
-	backtrack(ctx, g, current, idx+1, bestCount, bestSolution)
+	return terms
+}
 
+func multiplyDNF(ctx context.Context, left, right []bitset) []bitset {
+	product := make([]bitset, 0, len(left)*len(right))
+	for _, a := range left {
+		if ctx.Err() != nil {
+			return nil
+		}
+		for _, b := range right {
+			product = append(product, a.Union(b))
+		}
+	}
+	return absorb(ctx, product)
 }
 
-func checkSolution[T comparable](g *graph[T], x []bool) bool {
-	for i := range x {
-		if x[i] {
-			for j := i + 1; j < len(x); j++ {
-				if x[j] && (g.cache.AdjMatrix.Get(i, j) || g.cache.AdjMatrix.Get(j, i)) {
-					return false
-				}
+func absorb(ctx context.Context, terms []bitset) []bitset {
+	slices.SortFunc(terms, func(a, b bitset) int {
+		return a.Count() - b.Count()
+	})
+
+	minimal := make([]bitset, 0, len(terms))
+	for i, term := range terms {
+		if i%1024 == 0 && ctx.Err() != nil {
+			return nil
+		}
+
+		absorbed := false
+		for _, kept := range minimal {
+			if kept.IsSubsetOf(term) {
+				absorbed = true
+				break
 			}
 		}
+		if !absorbed {
+			minimal = append(minimal, term)
+		}
 	}
-	return true
+	return minimal
 }
@@ -0,0 +1,124 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func bruteForceMaximalSets(g Graph[string]) [][]string {
+	vertices := g.GetAllVertices()
+	index := make(map[string]int, len(vertices))
+	for i, v := range vertices {
+		index[v] = i
+	}
+	conflicts := make([]uint32, len(vertices))
+	for _, e := range g.GetAllEdges() {
+		from, to := index[*e.From], index[*e.To]
+		conflicts[from] |= 1 << to
+		conflicts[to] |= 1 << from
+	}
+
+	var sets [][]string
+	for mask := uint32(0); mask < 1<<len(vertices); mask++ {
+		maximal := true
+		for i := range vertices {
+			in := mask>>i&1 == 1
+			if in && conflicts[i]&mask != 0 || !in && conflicts[i]&(mask|1<<i) == 0 {
+				maximal = false
+				break
+			}
+		}
+		if !maximal {
+			continue
+		}
+		var set []string
+		for i, v := range vertices {
+			if mask>>i&1 == 1 {
+				set = append(set, v)
+			}
+		}
+		sets = append(sets, set)
+	}
+	return sets
+}
+
+func TestMaghoutMaximalSets(t *testing.T) {
+	tests := []struct {
+		name string
+		g    Graph[string]
+	}{
+		{"одна вершина", buildGraph(1, nil)},
+		{"без рёбер", buildGraph(4, nil)},
+		{"путь P5", buildGraph(5, pathEdges(5))},
+		{"цикл C7", buildGraph(7, cycleEdges(7))},
+		{"полный граф K5", buildGraph(5, completeEdges(5))},
+		{"петля", buildGraph(4, [][2]int{{0, 1}, {2, 2}, {2, 3}})},
+	}
+	for seed := range int64(30) {
+		n := 2 + int(seed)%12
+		p := []float64{0.15, 0.3, 0.5, 0.7}[seed%4]
+		tests = append(tests, struct {
+			name string
+			g    Graph[string]
+		}{fmt.Sprintf("случайный граф n=%d p=%.2f", n, p), randomGraph(Undirected, n, p, seed)})
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := bruteForceMaximalSets(tt.g)
+			alpha := bruteForceAlpha(tt.g)
+
+			for _, depth := range []int{0, 2} {
+				result := MaghoutMaximalSets(context.Background(), tt.g, depth)
+				if result == nil {
+					t.Fatalf("глубина %d: нет результата", depth)
+				}
+				if result.IndependenceNumber != alpha || len(result.MaximalSets[0]) != alpha {
+					t.Fatalf("глубина %d: α = %d, первое множество из %d вершин, ожидалось %d", depth, result.IndependenceNumber, len(result.MaximalSets[0]), alpha)
+				}
+				if !slices.Equal(setKeys(result.MaximalSets), setKeys(want)) {
+					t.Fatalf("глубина %d: найдено %d максимальных множеств, ожидалось %d", depth, len(result.MaximalSets), len(want))
+				}
+				for _, set := range result.MaximalSets {
+					if check := VerifyIndependentSet(tt.g, set); !check.Valid() {
+						t.Fatal(check)
+					}
+				}
+			}
+		})
+	}
+
+	if result := MaghoutMaximalSets(context.Background(), buildGraph(0, nil), 0); result != nil {
+		t.Fatalf("пустой граф: %+v", result)
+	}
+}
+
+func TestMaghoutSolverOptions(t *testing.T) {
+	solver, err := DefaultRegistry[string]().New(MaghoutSolver, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := randomGraph(Undirected, 14, 0.3, 4)
+	alpha := bruteForceAlpha(g)
+
+	solve := func(seed int64) []Improvement[string] {
+		var improvements []Improvement[string]
+		solution, _ := solver.Solve(context.Background(), g, WithSeed(seed), WithImprovements(func(imp Improvement[string]) {
+			improvements = append(improvements, imp)
+		}))
+		if len(improvements) == 0 {
+			t.Fatal("метод не сообщил ни одного улучшения")
+		}
+		if last := improvements[len(improvements)-1]; last.Size != alpha || len(solution.Vertices) != alpha {
+			t.Fatalf("последнее улучшение %d, решение %d, α = %d", last.Size, len(solution.Vertices), alpha)
+		}
+		return improvements
+	}
+
+	first, second := solve(3), solve(3)
+	if len(first) != len(second) || !slices.Equal(first[0].Vertices, second[0].Vertices) {
+		t.Fatal("при одинаковом зерне получены разные улучшения")
+	}
+}
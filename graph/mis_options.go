@@ -0,0 +1,93 @@
+package graph
+
+import (
+	"context"
+	"math/rand"
+)
+
+type MISOption func(*misOptions)
+
+type misOptions struct {
+	kernelize        bool
+	components       bool
+	componentWorkers int
+	weighted         bool
+	onImprove        any
+	seed             int64
+	seeded           bool
+}
+
+func WithKernelization() MISOption {
+	return func(o *misOptions) {
+		o.kernelize = true
+	}
+}
+
+func WithComponentDecomposition(workers int) MISOption {
+	return func(o *misOptions) {
+		o.components = true
+		o.componentWorkers = workers
+	}
+}
+
+func WithSeed(seed int64) MISOption {
+	return func(o *misOptions) {
+		o.seed = seed
+		o.seeded = true
+	}
+}
+
+func WithImprovements[T comparable](fn func(Improvement[T])) MISOption {
+	return func(o *misOptions) {
+		if prev, ok := o.onImprove.(func(Improvement[T])); ok && prev != nil {
+			o.onImprove = func(imp Improvement[T]) {
+				prev(imp)
+				fn(imp)
+			}
+			return
+		}
+		o.onImprove = fn
+	}
+}
+
+func WithImprovementChannel[T comparable](ctx context.Context, ch chan<- Improvement[T]) MISOption {
+	return WithImprovements(func(imp Improvement[T]) {
+		select {
+		case ch <- imp:
+		case <-ctx.Done():
+		}
+	})
+}
+
+func withoutComponentDecomposition() MISOption {
+	return func(o *misOptions) {
+		o.components = false
+	}
+}
+
+func withImprovementHook(fn any) MISOption {
+	return func(o *misOptions) {
+		o.onImprove = fn
+	}
+}
+
+func withWeightedObjective(opts []MISOption) []MISOption {
+	return append(opts[:len(opts):len(opts)], func(o *misOptions) {
+		o.weighted = true
+	})
+}
+
+func (o misOptions) rng() *rand.Rand {
+	if o.seeded {
+		return rand.New(rand.NewSource(o.seed))
+	}
+	return rand.New(rand.NewSource(rand.Int63()))
+}
+
+func applyMISOptions(opts []MISOption) misOptions {
+	var o misOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
@@ -0,0 +1,151 @@
+package graph
+
+import (
+	"fmt"
+	"strings"
+)
+
+type ViolationKind int
+
+const (
+	UnknownVertex ViolationKind = iota
+	DuplicateVertex
+	LoopVertex
+	AdjacentVertices
+	NotMaximal
+)
+
+func (k ViolationKind) String() string {
+	switch k {
+	case UnknownVertex:
+		return "вершина отсутствует в графе"
+	case DuplicateVertex:
+		return "вершина повторяется"
+	case LoopVertex:
+		return "вершина с петлёй"
+	case AdjacentVertices:
+		return "смежные вершины"
+	case NotMaximal:
+		return "множество не максимально"
+	}
+	return "неизвестное нарушение"
+}
+
+type Violation[T comparable] struct {
+	Kind     ViolationKind
+	Vertices []T
+}
+
+func (v Violation[T]) String() string {
+	parts := make([]string, len(v.Vertices))
+	for i, vertex := range v.Vertices {
+		parts[i] = fmt.Sprint(vertex)
+	}
+	return fmt.Sprintf("%s: %s", v.Kind, strings.Join(parts, ", "))
+}
+
+type Verification[T comparable] struct {
+	Violations []Violation[T]
+}
+
+func (v Verification[T]) Independent() bool {
+	for _, violation := range v.Violations {
+		if violation.Kind != NotMaximal {
+			return false
+		}
+	}
+	return true
+}
+
+func (v Verification[T]) Maximal() bool {
+	for _, violation := range v.Violations {
+		if violation.Kind == NotMaximal {
+			return false
+		}
+	}
+	return true
+}
+
+func (v Verification[T]) Valid() bool {
+	return len(v.Violations) == 0
+}
+
+func (v Verification[T]) String() string {
+	if v.Valid() {
+		return "решение корректно"
+	}
+	parts := make([]string, len(v.Violations))
+	for i, violation := range v.Violations {
+		parts[i] = violation.String()
+	}
+	return strings.Join(parts, "; ")
+}
+
+func VerifyIndependentSet[T comparable](g Graph[T], solution []T) Verification[T] {
+	var result Verification[T]
+	report := func(kind ViolationKind, vertices ...T) {
+		result.Violations = append(result.Violations, Violation[T]{Kind: kind, Vertices: vertices})
+	}
+
+	vertices := g.GetAllVertices()
+	known := make(map[T]bool, len(vertices))
+	for _, v := range vertices {
+		known[v] = true
+	}
+
+	adj := make(map[T]map[T]bool, len(vertices))
+	for _, e := range g.GetAllEdges() {
+		from, to := *e.From, *e.To
+		if adj[from] == nil {
+			adj[from] = make(map[T]bool)
+		}
+		if adj[to] == nil {
+			adj[to] = make(map[T]bool)
+		}
+		adj[from][to] = true
+		adj[to][from] = true
+	}
+
+	inSet := make(map[T]bool, len(solution))
+	var members []T
+	for _, v := range solution {
+		switch {
+		case !known[v]:
+			report(UnknownVertex, v)
+		case inSet[v]:
+			report(DuplicateVertex, v)
+		default:
+			inSet[v] = true
+			members = append(members, v)
+		}
+	}
+
+	for i, u := range members {
+		if adj[u][u] {
+			report(LoopVertex, u)
+		}
+		for _, v := range members[i+1:] {
+			if adj[u][v] {
+				report(AdjacentVertices, u, v)
+			}
+		}
+	}
+
+	for _, v := range vertices {
+		if inSet[v] || adj[v][v] {
+			continue
+		}
+		blocked := false
+		for u := range adj[v] {
+			if inSet[u] {
+				blocked = true
+				break
+			}
+		}
+		if !blocked {
+			report(NotMaximal, v)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,56 @@
+package graph
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestVerifyIndependentSet(t *testing.T) {
+	g := buildGraph(5, [][2]int{{0, 1}, {1, 2}, {2, 3}, {4, 4}})
+
+	violation := func(kind ViolationKind, vertices ...string) string {
+		return Violation[string]{Kind: kind, Vertices: vertices}.String()
+	}
+
+	tests := []struct {
+		name        string
+		solution    []string
+		violations  []string
+		independent bool
+		maximal     bool
+	}{
+		{"корректное решение", []string{"v0", "v2"}, nil, true, true},
+		{"другое корректное решение", []string{"v0", "v3"}, nil, true, true},
+		{"смежная пара", []string{"v0", "v1", "v3"}, []string{violation(AdjacentVertices, "v0", "v1")}, false, true},
+		{"повтор", []string{"v0", "v2", "v0"}, []string{violation(DuplicateVertex, "v0")}, false, true},
+		{"неизвестная вершина", []string{"v0", "v2", "x"}, []string{violation(UnknownVertex, "x")}, false, true},
+		{"петля", []string{"v0", "v2", "v4"}, []string{violation(LoopVertex, "v4")}, false, true},
+		{"не максимально", []string{"v0"}, []string{violation(NotMaximal, "v2"), violation(NotMaximal, "v3")}, true, false},
+		{"пустое решение", nil, []string{
+			violation(NotMaximal, "v0"), violation(NotMaximal, "v1"), violation(NotMaximal, "v2"), violation(NotMaximal, "v3"),
+		}, true, false},
+		{"несколько нарушений", []string{"v1", "v2", "v1"}, []string{
+			violation(DuplicateVertex, "v1"), violation(AdjacentVertices, "v1", "v2"),
+		}, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := VerifyIndependentSet(g, tt.solution)
+
+			var got []string
+			for _, v := range check.Violations {
+				got = append(got, v.String())
+			}
+			slices.Sort(got)
+			want := slices.Sorted(slices.Values(tt.violations))
+			if !slices.Equal(got, want) {
+				t.Fatalf("нарушения %q, ожидалось %q", got, want)
+			}
+
+			if check.Independent() != tt.independent || check.Maximal() != tt.maximal || check.Valid() != (len(tt.violations) == 0) {
+				t.Fatalf("независимо = %v, максимально = %v, корректно = %v", check.Independent(), check.Maximal(), check.Valid())
+			}
+		})
+	}
+}
@@ -0,0 +1,342 @@
+package graph
+
+import (
+	"container/heap"
+	"context"
+	"slices"
+)
+
+func SolutionWeight[T comparable](g Graph[T], solution []T) float64 {
+	s := snapshotOf(g)
+	if s == nil {
+		return 0
+	}
+
+	total := 0.0
+	for _, v := range solution {
+		if idx, ok := s.vertexToIndex[v]; ok {
+			total += s.Weight(idx)
+		}
+	}
+	return total
+}
+
+func MWISGreedy[T comparable](ctx context.Context, g Graph[T]) []T {
+	s := snapshotOf(g)
+	if s == nil {
+		return nil
+	}
+
+	return s.Vertices(weightedGreedyIndices(ctx, s))
+}
+
+func MWISMaghout[T comparable](ctx context.Context, g Graph[T], parallelDepth int, opts ...MISOption) []T {
+	opts = withWeightedObjective(opts)
+	o := applyMISOptions(opts)
+
+	if o.components {
+		return solveByComponents(ctx, g, opts, func(component Graph[T], opts ...MISOption) []T {
+			return MWISMaghout(ctx, component, parallelDepth, opts...)
+		})
+	}
+
+	if o.kernelize {
+		return solveOnKernel(ctx, g, opts, func(kernel Graph[T], opts ...MISOption) []T {
+			return MWISMaghout(ctx, kernel, parallelDepth, opts...)
+		})
+	}
+
+	s := snapshotOf(g)
+	if s == nil {
+		return nil
+	}
+
+	reporter := newImprovementReporter(o, s)
+	if reporter != nil {
+		reporter.reportIndices(weightedGreedyIndices(ctx, s))
+	}
+
+	result := MaghoutMaximalSets(ctx, g, parallelDepth)
+	if result == nil || len(result.MaximalSets) == 0 {
+		if s.Size() == 0 {
+			return nil
+		}
+		return s.Vertices(fallbackIndices(s, o))
+	}
+
+	var best []T
+	bestWeight := -1.0
+	for _, set := range result.MaximalSets {
+		positive := make([]T, 0, len(set))
+		weight := 0.0
+		for _, v := range set {
+			if w := s.Weight(s.vertexToIndex[v]); w > 0 {
+				positive = append(positive, v)
+				weight += w
+			}
+		}
+		if weight > bestWeight {
+			best, bestWeight = positive, weight
+		}
+	}
+
+	reporter.report(best)
+	return best
+}
+
+func MWISBranchAndBound[T comparable](ctx context.Context, g Graph[T], opts ...MISOption) []T {
+	opts = withWeightedObjective(opts)
+	o := applyMISOptions(opts)
+
+	if o.components {
+		return solveByComponents(ctx, g, opts, func(component Graph[T], opts ...MISOption) []T {
+			return MWISBranchAndBound(ctx, component, opts...)
+		})
+	}
+
+	if o.kernelize {
+		return solveOnKernel(ctx, g, opts, func(kernel Graph[T], opts ...MISOption) []T {
+			return MWISBranchAndBound(ctx, kernel, opts...)
+		})
+	}
+
+	s := snapshotOf(g)
+	if s == nil {
+		return nil
+	}
+
+	n := s.Size()
+	if n == 0 {
+		return nil
+	}
+
+	order, neighbors := orderForBranchAndBound(s.Matrix())
+
+	weights := make([]float64, n)
+	for i, v := range order {
+		weights[i] = s.Weight(v)
+	}
+
+	reporter := newImprovementReporter(o, s)
+
+	bb := &weightedBranchAndBound{
+		ctx:       ctx,
+		budget:    budgetOf(ctx),
+		neighbors: neighbors,
+		weights:   weights,
+		current:   make([]int, 0, n),
+	}
+	bb.onImprove = reportInOrder(reporter, order)
+
+	candidates := newBitset(n)
+	for i := range n {
+		if !neighbors.Get(i, i) && weights[i] > 0 {
+			candidates.Set(i)
+		}
+	}
+
+	bb.best = weightedGreedyIndependentSet(neighbors, candidates, weights)
+	for _, v := range bb.best {
+		bb.bestWeight += weights[v]
+	}
+	bb.improved()
+	bb.expand(candidates, 0)
+
+	result := make([]T, len(bb.best))
+	for i, idx := range bb.best {
+		result[i] = s.indexToVertex[order[idx]]
+	}
+	return result
+}
+
+type weightedBranchAndBound struct {
+	ctx        context.Context
+	budget     *budget
+	neighbors  *adjMatrix
+	weights    []float64
+	current    []int
+	best       []int
+	bestWeight float64
+	onImprove  func(best []int)
+}
+
+func (bb *weightedBranchAndBound) improved() {
+	if bb.onImprove != nil {
+		bb.onImprove(bb.best)
+	}
+}
+
+func (bb *weightedBranchAndBound) expand(candidates bitset, weight float64) {
+	bb.budget.spend()
+	if bb.ctx.Err() != nil {
+		return
+	}
+
+	order, bounds := weightedCliqueCoverBounds(bb.neighbors, candidates, bb.weights)
+
+	for k := len(order) - 1; k >= 0; k-- {
+		if weight+bounds[k] <= bb.bestWeight {
+			return
+		}
+
+		v := order[k]
+		bb.current = append(bb.current, v)
+
+		next := candidates.Clone()
+		next.Clear(v)
+		next.AndNot(bb.neighbors.Row(v))
+
+		if next.IsEmpty() {
+			if weight+bb.weights[v] > bb.bestWeight {
+				bb.best = slices.Clone(bb.current)
+				bb.bestWeight = weight + bb.weights[v]
+				bb.improved()
+			}
+		} else {
+			bb.expand(next, weight+bb.weights[v])
+		}
+
+		bb.current = bb.current[:len(bb.current)-1]
+		candidates.Clear(v)
+
+		if bb.ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+func weightedCliqueCoverBounds(neighbors *adjMatrix, candidates bitset, weights []float64) ([]int, []float64) {
+	uncovered := candidates.Clone()
+	order := make([]int, 0, candidates.Count())
+	bounds := make([]float64, 0, cap(order))
+
+	completed := 0.0
+	for !uncovered.IsEmpty() {
+		heaviest := 0.0
+		clique := uncovered.Clone()
+		for !clique.IsEmpty() {
+			v := clique.First()
+			uncovered.Clear(v)
+			clique.Clear(v)
+			clique.And(neighbors.Row(v))
+
+			heaviest = max(heaviest, weights[v])
+			order = append(order, v)
+			bounds = append(bounds, completed+heaviest)
+		}
+		completed += heaviest
+	}
+
+	return order, bounds
+}
+
+func weightedGreedyIndependentSet(neighbors *adjMatrix, candidates bitset, weights []float64) []int {
+	available := candidates.Clone()
+	var result []int
+	for !available.IsEmpty() {
+		best := -1
+		available.ForEach(neighbors.Size(), func(v int) {
+			if best < 0 || weights[v] > weights[best] {
+				best = v
+			}
+		})
+		result = append(result, best)
+		available.Clear(best)
+		available.AndNot(neighbors.Row(best))
+	}
+	return result
+}
+
+func weightedGreedyIndices[T comparable](ctx context.Context, s *snapshot[T]) []int {
+	adj := s.CSR()
+	n := adj.Size()
+	alive := make([]bool, n)
+	degree := make([]int, n)
+
+	for i := range n {
+		alive[i] = s.Weight(i) > 0 && !adj.Get(i, i)
+	}
+
+	queue := make(weightedQueue, 0, n)
+	for i := range n {
+		if !alive[i] {
+			continue
+		}
+		for _, j := range adj.Row(i) {
+			if alive[j] {
+				degree[i]++
+			}
+		}
+		queue = append(queue, weightedItem{vertex: i, degree: degree[i], priority: s.Weight(i) / float64(degree[i]+1)})
+	}
+	heap.Init(&queue)
+
+	var solutionIndices, removed []int
+	for queue.Len() > 0 {
+		if ctx.Err() != nil {
+			return solutionIndices
+		}
+
+		item := heap.Pop(&queue).(weightedItem)
+		v := item.vertex
+		if !alive[v] || item.degree != degree[v] {
+			continue
+		}
+
+		solutionIndices = append(solutionIndices, v)
+		alive[v] = false
+		removed = removed[:0]
+		for _, u := range adj.Row(v) {
+			if alive[u] {
+				alive[u] = false
+				removed = append(removed, u)
+			}
+		}
+
+		for _, u := range removed {
+			for _, w := range adj.Row(u) {
+				if !alive[w] {
+					continue
+				}
+				degree[w]--
+				heap.Push(&queue, weightedItem{vertex: w, degree: degree[w], priority: s.Weight(w) / float64(degree[w]+1)})
+			}
+		}
+	}
+
+	return solutionIndices
+}
+
+type weightedItem struct {
+	vertex   int
+	degree   int
+	priority float64
+}
+
+type weightedQueue []weightedItem
+
+func (q weightedQueue) Len() int {
+	return len(q)
+}
+
+func (q weightedQueue) Less(i, j int) bool {
+	if q[i].priority != q[j].priority {
+		return q[i].priority > q[j].priority
+	}
+	return q[i].vertex < q[j].vertex
+}
+
+func (q weightedQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+}
+
+func (q *weightedQueue) Push(x any) {
+	*q = append(*q, x.(weightedItem))
+}
+
+func (q *weightedQueue) Pop() any {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
@@ -0,0 +1,232 @@
+package graph
+
+import (
+	"cmp"
+	"context"
+	"slices"
+)
+
+const weightEpsilon = 1e-9
+
+func MWISLocalSearch[T comparable](ctx context.Context, g Graph[T], genome []bool, localIters int, opts ...MISOption) []bool {
+	s := snapshotOf(g)
+	if s == nil {
+		return nil
+	}
+
+	reporter := newImprovementReporter(applyMISOptions(withWeightedObjective(opts)), s)
+	return weightedLocalSearch(ctx, s, genome, localIters, reporter.reportGenome)
+}
+
+func MWISGreedySearch[T comparable](ctx context.Context, g Graph[T], localIters int, opts ...MISOption) []T {
+	opts = withWeightedObjective(opts)
+	o := applyMISOptions(opts)
+
+	if o.components {
+		return solveByComponents(ctx, g, opts, func(component Graph[T], opts ...MISOption) []T {
+			return MWISGreedySearch(ctx, component, localIters, opts...)
+		})
+	}
+
+	s := snapshotOf(g)
+	if s == nil {
+		return nil
+	}
+
+	genome := make([]bool, s.Size())
+
+	if o.kernelize {
+		lifted := solveOnKernel(ctx, g, append(opts[:len(opts):len(opts)], withImprovementHook(nil)), func(kernel Graph[T], opts ...MISOption) []T {
+			return MWISGreedySearch(ctx, kernel, localIters, opts...)
+		})
+		for _, v := range lifted {
+			genome[s.vertexToIndex[v]] = true
+		}
+		localIters = max(localIters, 1)
+	} else {
+		for _, idx := range weightedGreedyIndices(ctx, s) {
+			genome[idx] = true
+		}
+	}
+
+	reporter := newImprovementReporter(o, s)
+	improved := weightedLocalSearch(ctx, s, genome, localIters, reporter.reportGenome)
+
+	result := make([]T, 0, computeCardinality(improved))
+	for i, inc := range improved {
+		if inc {
+			result = append(result, s.indexToVertex[i])
+		}
+	}
+	return result
+}
+
+func weightedLocalSearch[T comparable](ctx context.Context, s *snapshot[T], genome []bool, localIters int, report func(genome []bool)) []bool {
+	ls := newWeightedSwapSearch(s)
+	for i, inc := range genome {
+		if inc && ls.insertable(i) {
+			ls.insert(i)
+		}
+	}
+	ls.fillFree(ls.byWeight)
+	report(ls.inSolution)
+
+	budget := budgetOf(ctx)
+	for range localIters {
+		budget.spend()
+		if ctx.Err() != nil || !ls.improve(ctx) {
+			break
+		}
+		report(ls.inSolution)
+	}
+
+	return slices.Clone(ls.inSolution)
+}
+
+type weightedSwapSearch struct {
+	csr     *csrMatrix
+	weights []float64
+
+	inSolution []bool
+	blocked    []bool
+	tight      []int
+	conflict   []float64
+	byWeight   []int
+}
+
+func newWeightedSwapSearch[T comparable](s *snapshot[T]) *weightedSwapSearch {
+	n := s.Size()
+	ls := &weightedSwapSearch{
+		csr:        s.CSR(),
+		weights:    s.weights,
+		inSolution: make([]bool, n),
+		blocked:    make([]bool, n),
+		tight:      make([]int, n),
+		conflict:   make([]float64, n),
+		byWeight:   make([]int, n),
+	}
+
+	for v := range n {
+		ls.blocked[v] = ls.weights[v] <= 0 || ls.csr.Get(v, v)
+		ls.byWeight[v] = v
+	}
+	ls.sortByWeight(ls.byWeight)
+	return ls
+}
+
+func (ls *weightedSwapSearch) sortByWeight(vertices []int) {
+	slices.SortStableFunc(vertices, func(a, b int) int {
+		return cmp.Compare(ls.weights[b], ls.weights[a])
+	})
+}
+
+func (ls *weightedSwapSearch) insertable(v int) bool {
+	return !ls.inSolution[v] && !ls.blocked[v] && ls.tight[v] == 0
+}
+
+func (ls *weightedSwapSearch) insert(v int) {
+	ls.inSolution[v] = true
+	for _, u := range ls.csr.Row(v) {
+		ls.tight[u]++
+		ls.conflict[u] += ls.weights[v]
+	}
+}
+
+func (ls *weightedSwapSearch) remove(v int) {
+	ls.inSolution[v] = false
+	for _, u := range ls.csr.Row(v) {
+		ls.tight[u]--
+		ls.conflict[u] -= ls.weights[v]
+	}
+}
+
+func (ls *weightedSwapSearch) fillFree(candidates []int) {
+	for _, v := range candidates {
+		if ls.insertable(v) {
+			ls.insert(v)
+		}
+	}
+}
+
+func (ls *weightedSwapSearch) improve(ctx context.Context) bool {
+	improved := false
+	for v := range ls.inSolution {
+		if ctx.Err() != nil {
+			return false
+		}
+		if !ls.inSolution[v] && !ls.blocked[v] && ls.weights[v] > ls.conflict[v]+weightEpsilon {
+			ls.swapIn(v)
+			improved = true
+		}
+	}
+
+	for x := range ls.inSolution {
+		if ctx.Err() != nil {
+			return false
+		}
+		if ls.inSolution[x] && ls.replace(x) {
+			improved = true
+		}
+	}
+	return improved
+}
+
+func (ls *weightedSwapSearch) swapIn(v int) {
+	var removed []int
+	for _, u := range ls.csr.Row(v) {
+		if ls.inSolution[u] {
+			ls.remove(u)
+			removed = append(removed, u)
+		}
+	}
+	ls.insert(v)
+
+	var freed []int
+	for _, u := range removed {
+		freed = append(freed, ls.csr.Row(u)...)
+	}
+	ls.sortByWeight(freed)
+	ls.fillFree(freed)
+}
+
+func (ls *weightedSwapSearch) replace(x int) bool {
+	var candidates []int
+	for _, u := range ls.csr.Row(x) {
+		if !ls.inSolution[u] && !ls.blocked[u] && ls.tight[u] == 1 {
+			candidates = append(candidates, u)
+		}
+	}
+	if len(candidates) < 2 {
+		return false
+	}
+	ls.sortByWeight(candidates)
+
+	var chosen []int
+	total := 0.0
+	for _, u := range candidates {
+		independent := true
+		for _, w := range chosen {
+			if ls.csr.Get(u, w) {
+				independent = false
+				break
+			}
+		}
+		if independent {
+			chosen = append(chosen, u)
+			total += ls.weights[u]
+		}
+	}
+	if total <= ls.weights[x]+weightEpsilon {
+		return false
+	}
+
+	ls.remove(x)
+	for _, u := range chosen {
+		ls.insert(u)
+	}
+
+	freed := slices.Clone(ls.csr.Row(x))
+	ls.sortByWeight(freed)
+	ls.fillFree(freed)
+	return true
+}
@@ -0,0 +1,75 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func randomWeightedGraph(n int, p float64, seed int64) Graph[string] {
+	g := randomGraph(Undirected, n, p, seed)
+	rng := rand.New(rand.NewSource(seed))
+	for _, v := range g.GetAllVertices() {
+		weight := float64(rng.Intn(12) - 2)
+		g.SetVertexWeight(&v, weight)
+	}
+	return g
+}
+
+func bruteForceMaxWeight(g Graph[string]) float64 {
+	best := 0.0
+	for _, set := range bruteForceMaximalSets(g) {
+		weight := 0.0
+		for _, v := range set {
+			if w, _ := g.GetVertexWeight(&v); w > 0 {
+				weight += w
+			}
+		}
+		best = max(best, weight)
+	}
+	return best
+}
+
+func TestMWISOptimum(t *testing.T) {
+	solvers := []struct {
+		name  string
+		solve func(g Graph[string]) []string
+	}{
+		{"метод ветвей и границ", func(g Graph[string]) []string {
+			return MWISBranchAndBound(context.Background(), g)
+		}},
+		{"метод ветвей и границ с ядром", func(g Graph[string]) []string {
+			return MWISBranchAndBound(context.Background(), g, WithKernelization())
+		}},
+		{"метод ветвей и границ по компонентам", func(g Graph[string]) []string {
+			return MWISBranchAndBound(context.Background(), g, WithComponentDecomposition(2))
+		}},
+		{"метод Магу", func(g Graph[string]) []string {
+			return MWISMaghout(context.Background(), g, 1)
+		}},
+		{"метод Магу с ядром", func(g Graph[string]) []string {
+			return MWISMaghout(context.Background(), g, 1, WithKernelization())
+		}},
+	}
+
+	for seed := range int64(40) {
+		n := 1 + int(seed)%14
+		p := []float64{0.1, 0.25, 0.4, 0.6}[seed%4]
+		g := randomWeightedGraph(n, p, seed)
+		want := bruteForceMaxWeight(g)
+
+		for _, solver := range solvers {
+			t.Run(fmt.Sprintf("%s, n=%d p=%.2f", solver.name, n, p), func(t *testing.T) {
+				result := solver.solve(g)
+				if got := SolutionWeight(g, result); math.Abs(got-want) > 1e-9 {
+					t.Fatalf("вес %g, ожидалось %g", got, want)
+				}
+				if check := VerifyIndependentSet(g, result); !check.Independent() {
+					t.Fatal(check)
+				}
+			})
+		}
+	}
+}
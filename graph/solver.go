@@ -0,0 +1,203 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+type Solution[T comparable] struct {
+	Vertices []T
+	Weight   float64
+	Optima   *MaximumSets[T]
+}
+
+type Stats struct {
+	Elapsed     time.Duration
+	Optimal     bool
+	Interrupted bool
+	Status      RunStatus
+	Seed        int64
+	Trace       []TracePoint
+	Counters    map[string]int
+}
+
+type TracePoint struct {
+	Elapsed time.Duration
+	Size    int
+	Weight  float64
+}
+
+type Solver[T comparable] interface {
+	Name() string
+	Exact() bool
+	Params() []ParamSpec
+	Config() Params
+	Limits() Limits
+	Solve(ctx context.Context, g Graph[T], opts ...MISOption) (Solution[T], Stats)
+}
+
+type ParamKind int
+
+const (
+	IntParam ParamKind = iota
+	FloatParam
+	BoolParam
+	DurationParam
+)
+
+type ParamSpec struct {
+	Name    string
+	Label   string
+	Kind    ParamKind
+	Default any
+	Min     float64
+	Max     float64
+}
+
+func (p ParamSpec) Parse(text string) (any, error) {
+	switch p.Kind {
+	case IntParam:
+		i, err := strconv.Atoi(text)
+		if err != nil {
+			return nil, fmt.Errorf("%s: ожидается целое число", p.Label)
+		}
+		return p.Normalize(i)
+	case FloatParam:
+		f, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s: ожидается число", p.Label)
+		}
+		return p.Normalize(f)
+	case BoolParam:
+		b, err := strconv.ParseBool(text)
+		if err != nil {
+			return nil, fmt.Errorf("%s: ожидается логическое значение", p.Label)
+		}
+		return p.Normalize(b)
+	case DurationParam:
+		if ms, err := strconv.Atoi(text); err == nil {
+			return p.Normalize(time.Duration(ms) * time.Millisecond)
+		}
+		d, err := time.ParseDuration(text)
+		if err != nil {
+			return nil, fmt.Errorf("%s: ожидается длительность в миллисекундах", p.Label)
+		}
+		return p.Normalize(d)
+	}
+	return nil, fmt.Errorf("%s: неизвестный тип параметра", p.Label)
+}
+
+func (p ParamSpec) Normalize(value any) (any, error) {
+	if text, ok := value.(string); ok && p.Kind != BoolParam {
+		return p.Parse(text)
+	}
+
+	switch p.Kind {
+	case IntParam:
+		var i int
+		switch v := value.(type) {
+		case int:
+			i = v
+		case int64:
+			i = int(v)
+		case float64:
+			if v != math.Trunc(v) {
+				return nil, fmt.Errorf("%s: ожидается целое число", p.Label)
+			}
+			i = int(v)
+		default:
+			return nil, fmt.Errorf("%s: ожидается целое число", p.Label)
+		}
+		return i, p.checkRange(float64(i))
+	case FloatParam:
+		var f float64
+		switch v := value.(type) {
+		case float64:
+			f = v
+		case int:
+			f = float64(v)
+		case int64:
+			f = float64(v)
+		default:
+			return nil, fmt.Errorf("%s: ожидается число", p.Label)
+		}
+		return f, p.checkRange(f)
+	case BoolParam:
+		switch v := value.(type) {
+		case bool:
+			return v, nil
+		case string:
+			return p.Parse(v)
+		}
+		return nil, fmt.Errorf("%s: ожидается логическое значение", p.Label)
+	case DurationParam:
+		var d time.Duration
+		switch v := value.(type) {
+		case time.Duration:
+			d = v
+		case int:
+			d = time.Duration(v) * time.Millisecond
+		case int64:
+			d = time.Duration(v) * time.Millisecond
+		case float64:
+			d = time.Duration(v * float64(time.Millisecond))
+		default:
+			return nil, fmt.Errorf("%s: ожидается длительность в миллисекундах", p.Label)
+		}
+		return d, p.checkRange(float64(d.Milliseconds()))
+	}
+	return nil, fmt.Errorf("%s: неизвестный тип параметра", p.Label)
+}
+
+func (p ParamSpec) checkRange(v float64) error {
+	if v < p.Min {
+		return fmt.Errorf("%s: значение должно быть не меньше %v", p.Label, p.Min)
+	}
+	if p.Max > p.Min && v > p.Max {
+		return fmt.Errorf("%s: значение должно быть не больше %v", p.Label, p.Max)
+	}
+	return nil
+}
+
+type Params map[string]any
+
+func (p Params) Int(name string) int {
+	v, _ := p[name].(int)
+	return v
+}
+
+func (p Params) Float(name string) float64 {
+	v, _ := p[name].(float64)
+	return v
+}
+
+func (p Params) Bool(name string) bool {
+	v, _ := p[name].(bool)
+	return v
+}
+
+func (p Params) Duration(name string) time.Duration {
+	v, _ := p[name].(time.Duration)
+	return v
+}
+
+func (p Params) limits() Limits {
+	return Limits{
+		TimeLimit: p.Duration(TimeLimitParam),
+		Budget:    p.Int(BudgetParam),
+	}
+}
+
+func (p Params) options() []MISOption {
+	var opts []MISOption
+	if p.Bool(KernelizeParam) {
+		opts = append(opts, WithKernelization())
+	}
+	if p.Bool(ComponentsParam) {
+		opts = append(opts, WithComponentDecomposition(0))
+	}
+	return opts
+}
@@ -0,0 +1,93 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	ErrTimeLimit       = errors.New("превышено ограничение времени")
+	ErrBudgetExhausted = errors.New("исчерпан бюджет итераций")
+)
+
+type Limits struct {
+	TimeLimit time.Duration
+	Budget    int
+}
+
+func WithLimits(ctx context.Context, limits Limits) (context.Context, context.CancelFunc) {
+	ctx, cancelCause := context.WithCancelCause(ctx)
+	cancel := func() { cancelCause(context.Canceled) }
+
+	if limits.Budget > 0 {
+		b := &budget{cancel: cancelCause}
+		b.remaining.Store(int64(limits.Budget))
+		ctx = context.WithValue(ctx, budgetKey{}, b)
+	}
+
+	if limits.TimeLimit > 0 {
+		var stop context.CancelFunc
+		ctx, stop = context.WithTimeoutCause(ctx, limits.TimeLimit, ErrTimeLimit)
+		return ctx, func() {
+			stop()
+			cancel()
+		}
+	}
+	return ctx, cancel
+}
+
+type RunStatus int
+
+const (
+	Finished RunStatus = iota
+	TimeLimitReached
+	BudgetExhausted
+	Cancelled
+)
+
+func (s RunStatus) String() string {
+	switch s {
+	case Finished:
+		return "завершено"
+	case TimeLimitReached:
+		return "лимит времени"
+	case BudgetExhausted:
+		return "бюджет исчерпан"
+	case Cancelled:
+		return "прервано"
+	}
+	return "неизвестно"
+}
+
+func runStatus(ctx context.Context) RunStatus {
+	cause := context.Cause(ctx)
+	switch {
+	case cause == nil:
+		return Finished
+	case errors.Is(cause, ErrTimeLimit):
+		return TimeLimitReached
+	case errors.Is(cause, ErrBudgetExhausted):
+		return BudgetExhausted
+	}
+	return Cancelled
+}
+
+type budgetKey struct{}
+
+type budget struct {
+	remaining atomic.Int64
+	cancel    context.CancelCauseFunc
+}
+
+func budgetOf(ctx context.Context) *budget {
+	b, _ := ctx.Value(budgetKey{}).(*budget)
+	return b
+}
+
+func (b *budget) spend() {
+	if b != nil && b.remaining.Add(-1) == -1 {
+		b.cancel(ErrBudgetExhausted)
+	}
+}
@@ -0,0 +1,358 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"math/rand"
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	MaghoutSolver        = "Метод Магу"
+	BranchAndBoundSolver = "Метод ветвей и границ"
+	GreedySearchSolver   = "Жадный поиск"
+	ILSSolver            = "Итерированный локальный поиск"
+	DynamicGreedySolver  = "Жадный (динамический порядок)"
+	StaticGreedySolver   = "Жадный (статический порядок)"
+	DegeneracySolver     = "Жадный (порядок вырожденности)"
+	ReverseGreedySolver  = "Обратный жадный"
+	GRASPSolver          = "GRASP"
+)
+
+const (
+	ParallelDepthParam = "parallel_depth"
+	KernelizeParam     = "kernelize"
+	ComponentsParam    = "components"
+	WeightedParam      = "weighted"
+	IterationsParam    = "iterations"
+	TimeLimitParam     = "time_limit"
+	BudgetParam        = "budget"
+	RestartsParam      = "restarts"
+	PerturbationParam  = "perturbation"
+	TabuTenureParam    = "tabu_tenure"
+	EnumerateParam     = "enumerate"
+	OptimaLimitParam   = "optima_limit"
+	RCLAlphaParam      = "rcl_alpha"
+)
+
+type SolverDescriptor[T comparable] struct {
+	Name      string
+	Exact     bool
+	Params    []ParamSpec
+	Solve     func(ctx context.Context, g Graph[T], params Params, opts []MISOption) ([]T, Stats)
+	Enumerate func(ctx context.Context, g Graph[T], params Params, opts []MISOption) MaximumSets[T]
+	Check     func(params Params) error
+}
+
+type Registry[T comparable] struct {
+	mu          sync.RWMutex
+	descriptors []SolverDescriptor[T]
+}
+
+func NewRegistry[T comparable]() *Registry[T] {
+	return &Registry[T]{}
+}
+
+func (r *Registry[T]) Register(d SolverDescriptor[T]) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.descriptors {
+		if existing.Name == d.Name {
+			return fmt.Errorf("метод %q уже зарегистрирован", d.Name)
+		}
+	}
+	r.descriptors = append(r.descriptors, d)
+	return nil
+}
+
+func (r *Registry[T]) Descriptors() []SolverDescriptor[T] {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return slices.Clone(r.descriptors)
+}
+
+func (r *Registry[T]) Lookup(name string) (SolverDescriptor[T], bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, d := range r.descriptors {
+		if d.Name == name {
+			return d, true
+		}
+	}
+	return SolverDescriptor[T]{}, false
+}
+
+func (r *Registry[T]) New(name string, params Params) (Solver[T], error) {
+	d, ok := r.Lookup(name)
+	if !ok {
+		return nil, fmt.Errorf("неизвестный метод %q", name)
+	}
+
+	config := make(Params, len(d.Params))
+	for _, spec := range d.Params {
+		value, ok := params[spec.Name]
+		if !ok {
+			config[spec.Name] = spec.Default
+			continue
+		}
+
+		normalized, err := spec.Normalize(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", d.Name, err)
+		}
+		config[spec.Name] = normalized
+	}
+
+	for key := range params {
+		if !slices.ContainsFunc(d.Params, func(spec ParamSpec) bool { return spec.Name == key }) {
+			return nil, fmt.Errorf("%s: неизвестный параметр %q", d.Name, key)
+		}
+	}
+
+	if d.Check != nil {
+		if err := d.Check(config); err != nil {
+			return nil, fmt.Errorf("%s: %w", d.Name, err)
+		}
+	}
+
+	return &configuredSolver[T]{descriptor: d, params: config}, nil
+}
+
+type configuredSolver[T comparable] struct {
+	descriptor SolverDescriptor[T]
+	params     Params
+}
+
+func (s *configuredSolver[T]) Name() string {
+	return s.descriptor.Name
+}
+
+func (s *configuredSolver[T]) Exact() bool {
+	return s.descriptor.Exact
+}
+
+func (s *configuredSolver[T]) Params() []ParamSpec {
+	return slices.Clone(s.descriptor.Params)
+}
+
+func (s *configuredSolver[T]) Config() Params {
+	return maps.Clone(s.params)
+}
+
+func (s *configuredSolver[T]) Limits() Limits {
+	return s.params.limits()
+}
+
+func (s *configuredSolver[T]) Solve(ctx context.Context, g Graph[T], opts ...MISOption) (Solution[T], Stats) {
+	var mu sync.Mutex
+	var trace []TracePoint
+	collect := WithImprovements(func(imp Improvement[T]) {
+		mu.Lock()
+		defer mu.Unlock()
+		trace = append(trace, TracePoint{Elapsed: imp.Elapsed, Size: imp.Size, Weight: imp.Weight})
+	})
+
+	o := applyMISOptions(opts)
+	seed := o.seed
+	if !o.seeded {
+		seed = rand.Int63()
+		opts = append([]MISOption{WithSeed(seed)}, opts...)
+	}
+
+	var vertices []T
+	var stats Stats
+	var optima *MaximumSets[T]
+
+	start := time.Now()
+	if s.descriptor.Enumerate != nil && s.params.Bool(EnumerateParam) {
+		sets := s.descriptor.Enumerate(ctx, g, s.params, append([]MISOption{collect}, opts...))
+		if len(sets.Sets) > 0 {
+			vertices = sets.Sets[0]
+		}
+		if ctx.Err() == nil {
+			optima = &sets
+			stats.Counters = map[string]int{
+				"α(G)":                sets.Alpha,
+				"Оптимальных решений": len(sets.Sets),
+			}
+		}
+	} else {
+		vertices, stats = s.descriptor.Solve(ctx, g, s.params, slices.Concat(s.params.options(), []MISOption{collect}, opts))
+	}
+	stats.Seed = seed
+	stats.Elapsed = time.Since(start)
+	stats.Interrupted = ctx.Err() != nil
+	stats.Status = runStatus(ctx)
+	stats.Optimal = s.descriptor.Exact && !stats.Interrupted
+
+	solution := Solution[T]{
+		Vertices: vertices,
+		Weight:   SolutionWeight(g, vertices),
+		Optima:   optima,
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(trace) == 0 || trace[len(trace)-1].Size != len(vertices) || trace[len(trace)-1].Weight != solution.Weight {
+		trace = append(trace, TracePoint{Elapsed: stats.Elapsed, Size: len(vertices), Weight: solution.Weight})
+	}
+	stats.Trace = trace
+
+	return solution, stats
+}
+
+func DefaultRegistry[T comparable]() *Registry[T] {
+	r := NewRegistry[T]()
+
+	kernelize := ParamSpec{Name: KernelizeParam, Label: "Сведение к ядру", Kind: BoolParam, Default: false}
+	components := ParamSpec{Name: ComponentsParam, Label: "Разбиение на компоненты связности", Kind: BoolParam, Default: false}
+	weighted := ParamSpec{Name: WeightedParam, Label: "Учитывать веса вершин (MWIS)", Kind: BoolParam, Default: false}
+	timeLimit := ParamSpec{Name: TimeLimitParam, Label: "Ограничение времени, мс (0 - без ограничения)", Kind: DurationParam, Default: time.Duration(0)}
+
+	r.Register(SolverDescriptor[T]{
+		Name:  MaghoutSolver,
+		Exact: true,
+		Params: []ParamSpec{
+			{Name: ParallelDepthParam, Label: "Глубина параллельности", Kind: IntParam, Default: 2, Min: 0, Max: 16},
+			kernelize,
+			components,
+			weighted,
+			timeLimit,
+			{Name: BudgetParam, Label: "Бюджет умножений дизъюнкций (0 - без ограничения)", Kind: IntParam, Default: 0},
+		},
+		Solve: func(ctx context.Context, g Graph[T], params Params, opts []MISOption) ([]T, Stats) {
+			depth := params.Int(ParallelDepthParam)
+
+			switch {
+			case params.Bool(WeightedParam):
+				return MWISMaghout(ctx, g, depth, opts...), Stats{}
+			case params.Bool(KernelizeParam) || params.Bool(ComponentsParam):
+				return MISMaghout(ctx, g, depth, opts...), Stats{}
+			}
+
+			result := MaghoutMaximalSets(ctx, g, depth, opts...)
+			if result == nil || len(result.MaximalSets) == 0 {
+				return MISMaghout(ctx, g, depth, opts...), Stats{}
+			}
+			return result.MaximalSets[0], Stats{Counters: map[string]int{
+				"α(G)": result.IndependenceNumber,
+				"Максимальных независимых множеств": len(result.MaximalSets),
+			}}
+		},
+	})
+
+	r.Register(SolverDescriptor[T]{
+		Name:  BranchAndBoundSolver,
+		Exact: true,
+		Params: []ParamSpec{
+			kernelize,
+			components,
+			weighted,
+			timeLimit,
+			{Name: BudgetParam, Label: "Бюджет узлов дерева поиска (0 - без ограничения)", Kind: IntParam, Default: 0},
+			{Name: EnumerateParam, Label: "Перечислять все оптимальные решения", Kind: BoolParam, Default: false},
+			{Name: OptimaLimitParam, Label: "Предел числа оптимальных решений (0 - без ограничения)", Kind: IntParam, Default: 0},
+		},
+		Solve: func(ctx context.Context, g Graph[T], params Params, opts []MISOption) ([]T, Stats) {
+			if params.Bool(WeightedParam) {
+				return MWISBranchAndBound(ctx, g, opts...), Stats{}
+			}
+			return MISBranchAndBound(ctx, g, opts...), Stats{}
+		},
+		Enumerate: func(ctx context.Context, g Graph[T], params Params, opts []MISOption) MaximumSets[T] {
+			return EnumerateMaximumIndependentSets(ctx, g, params.Int(OptimaLimitParam), opts...)
+		},
+		Check: func(params Params) error {
+			if params.Bool(EnumerateParam) && (params.Bool(KernelizeParam) || params.Bool(ComponentsParam) || params.Bool(WeightedParam)) {
+				return fmt.Errorf("перечисление оптимальных решений несовместимо со сведением к ядру, разбиением на компоненты и учётом весов")
+			}
+			return nil
+		},
+	})
+
+	r.Register(SolverDescriptor[T]{
+		Name: GreedySearchSolver,
+		Params: []ParamSpec{
+			{Name: IterationsParam, Label: "Итерации локального поиска", Kind: IntParam, Default: 10, Min: 1},
+			kernelize,
+			components,
+			weighted,
+			timeLimit,
+		},
+		Solve: func(ctx context.Context, g Graph[T], params Params, opts []MISOption) ([]T, Stats) {
+			if params.Bool(WeightedParam) {
+				return MWISGreedySearch(ctx, g, params.Int(IterationsParam), opts...), Stats{}
+			}
+			return MISGreedySearch(ctx, g, params.Int(IterationsParam), opts...), Stats{}
+		},
+	})
+
+	r.Register(SolverDescriptor[T]{
+		Name: ILSSolver,
+		Params: []ParamSpec{
+			{Name: IterationsParam, Label: "Итерации", Kind: IntParam, Default: 1000, Min: 1},
+			timeLimit,
+			{Name: BudgetParam, Label: "Бюджет итераций (0 - без ограничения)", Kind: IntParam, Default: 0},
+			{Name: RestartsParam, Label: "Число перезапусков", Kind: IntParam, Default: 0},
+			{Name: PerturbationParam, Label: "Сила возмущения", Kind: IntParam, Default: 1, Min: 1},
+			{Name: TabuTenureParam, Label: "Длительность запрета (табу)", Kind: IntParam, Default: 3},
+			kernelize,
+			components,
+		},
+		Solve: func(ctx context.Context, g Graph[T], params Params, opts []MISOption) ([]T, Stats) {
+			return MISIteratedLocalSearch(ctx, g, ILSConfig{
+				Iterations:   params.Int(IterationsParam),
+				Restarts:     params.Int(RestartsParam),
+				Perturbation: params.Int(PerturbationParam),
+				TabuTenure:   params.Int(TabuTenureParam),
+			}, opts...), Stats{}
+		},
+	})
+
+	for _, variant := range []struct {
+		name string
+		rule GreedyRule
+	}{
+		{DynamicGreedySolver, DynamicMinDegree},
+		{StaticGreedySolver, StaticMinDegree},
+		{DegeneracySolver, DegeneracyOrder},
+		{ReverseGreedySolver, MaxDegreeRemoval},
+	} {
+		r.Register(SolverDescriptor[T]{
+			Name:   variant.name,
+			Params: []ParamSpec{kernelize, components, timeLimit},
+			Solve: func(ctx context.Context, g Graph[T], params Params, opts []MISOption) ([]T, Stats) {
+				return MISGreedyVariant(ctx, g, GreedyConfig{Rule: variant.rule}, opts...), Stats{}
+			},
+		})
+	}
+
+	r.Register(SolverDescriptor[T]{
+		Name: GRASPSolver,
+		Params: []ParamSpec{
+			{Name: RCLAlphaParam, Label: "Порог списка кандидатов (0 - жадно, 1 - случайно)", Kind: FloatParam, Default: 0.3, Min: 0, Max: 1},
+			{Name: RestartsParam, Label: "Число построений", Kind: IntParam, Default: 10, Min: 1},
+			{Name: IterationsParam, Label: "Итерации локального поиска (0 - без поиска)", Kind: IntParam, Default: 0},
+			timeLimit,
+			{Name: BudgetParam, Label: "Бюджет построений и итераций (0 - без ограничения)", Kind: IntParam, Default: 0},
+			kernelize,
+			components,
+		},
+		Solve: func(ctx context.Context, g Graph[T], params Params, opts []MISOption) ([]T, Stats) {
+			return MISGreedyVariant(ctx, g, GreedyConfig{
+				Rule:       GRASP,
+				RCLAlpha:   params.Float(RCLAlphaParam),
+				Restarts:   params.Int(RestartsParam),
+				LocalIters: params.Int(IterationsParam),
+			}, opts...), Stats{}
+		},
+	})
+
+	return r
+}
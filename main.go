@@ -1,8 +1,17 @@
 package main
 
-import "graphmis/app"
+import (
+	"os"
+
+	"graphmis/app"
+	"graphmis/cli"
+)
 
 func main() {
+	if len(os.Args) > 1 {
+		os.Exit(cli.Run(os.Args[1:]))
+	}
+
 	a := app.NewApp()
 	a.ShowAndRun()
 }